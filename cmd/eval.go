@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/app"
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+)
+
+// eval scores every registered extractor on a labeled corpus and writes the
+// report to out as a table or as JSON.
+func eval(cfg *config.Config, extractors *app.ExtractorRegistry, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("eval", flag.ContinueOnError)
+	corpusPath := flags.String("corpus", "", "JSONL file of articles with their expected tags")
+	k := flags.Int("k", cfg.Extractor.MaxTags, "number of top tags scored per article (default $TAG_MAX_TAGS)")
+	format := flags.String("format", "table", `output format: "table" or "json"`)
+	diffs := flags.Bool("diffs", false, "list the missing and unexpected tags of every article in the table")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *corpusPath == "" {
+		return errors.New("no corpus: pass -corpus")
+	}
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	corpus, err := app.LoadEvalCorpus(*corpusPath)
+	if err != nil {
+		return err
+	}
+	report, err := app.Evaluate(extractors, corpus, *k)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return writeEvalTable(out, report, *diffs)
+}
+
+// writeEvalTable writes one row of averaged scores per extractor and, with
+// diffs, the scores and tag differences of every article below it.
+func writeEvalTable(out io.Writer, report *app.EvalReport, diffs bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%d articles, top %d tags\n\n", report.Articles, report.K)
+	fmt.Fprintln(w, "EXTRACTOR\tVERSION\tP@K\tP\tR@K\tF1\tMAP")
+	for _, e := range report.Extractors {
+		fmt.Fprintf(w, "%s\t%s\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\n", e.Extractor, e.Version, e.PrecisionAtK, e.Precision, e.Recall, e.F1, e.MAP)
+	}
+
+	if diffs {
+		for _, e := range report.Extractors {
+			fmt.Fprintf(w, "\n%s\nARTICLE\tP@K\tP\tR@K\tF1\tAP\tMISSING\tUNEXPECTED\n", e.Extractor)
+			for _, a := range e.Articles {
+				fmt.Fprintf(w, "%s\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%s\t%s\n", a.ID, a.PrecisionAtK, a.Precision, a.Recall, a.F1, a.AveragePrecision,
+					strings.Join(a.Missing, ", "), strings.Join(a.Unexpected, ", "))
+			}
+		}
+	}
+	return w.Flush()
+}
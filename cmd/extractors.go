@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/app"
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// extractorSources are the stored data some extractors read. A nil source
+// disables the extractor or decorator that needs it.
+type extractorSources struct {
+	documentFrequencies port.DocumentFrequencyRepository
+	vocabulary          port.VocabularyRepository
+	feedback            port.TagFeedbackRepository
+}
+
+// newExtractors registers the configured extractors and wraps them in the
+// configured decorators. Extractors reading a source refresh their snapshot in
+// the background until ctx is cancelled.
+func newExtractors(ctx context.Context, cfg *config.Config, sources extractorSources) *app.ExtractorRegistry {
+	// register the extractors requests may select
+	extractors := app.NewExtractorRegistry(cfg.Extractor.Algorithm)
+	skipped := make(map[string]bool)
+	for _, name := range cfg.Extractor.Available {
+		var tagExtractor port.TagExtractor
+		switch name {
+		case app.TFIDFExtractor:
+			if sources.documentFrequencies == nil {
+				log.Printf("warning: no document frequencies, skipping the %s extractor", name)
+				skipped[name] = true
+				continue
+			}
+			tfidfExtractor := app.NewTFIDFExtractorService(sources.documentFrequencies, cfg.Extractor)
+			go tfidfExtractor.StartRefresh(ctx, cfg.Extractor.RefreshInterval)
+			tagExtractor = tfidfExtractor
+		case app.RakeExtractor:
+			tagExtractor = app.NewRakeExtractorService(cfg.Extractor)
+		case app.TextRankExtractor:
+			tagExtractor = app.NewTextRankExtractorService(cfg.Extractor)
+		case app.FrequencyExtractor:
+			tagExtractor = app.NewTagExtractorServiceWithConfig(cfg.Extractor)
+		default:
+			log.Fatalf("unknown tag extractor: %s", name)
+		}
+		extractors.Register(name, app.ExtractorVersions[name], tagExtractor)
+	}
+
+	// fuse several extractors; registered before the decorators below so
+	// these wrap the ensemble once instead of each member
+	ensembleCfg := cfg.Ensemble
+	if len(skipped) > 0 && len(ensembleCfg.Weights) > 0 {
+		ensembleCfg.Weights = make(map[string]float64, len(cfg.Ensemble.Weights))
+		for name, weight := range cfg.Ensemble.Weights {
+			if skipped[name] {
+				log.Printf("warning: leaving the skipped %s extractor out of the ensemble", name)
+				continue
+			}
+			ensembleCfg.Weights[name] = weight
+		}
+	}
+	if len(ensembleCfg.Weights) > 0 {
+		ensemble, err := app.NewEnsembleExtractorService(extractors, ensembleCfg)
+		if err != nil {
+			log.Fatalf("failed to create ensemble extractor: %v", err)
+		}
+		extractors.Register(app.EnsembleExtractor, app.ExtractorVersions[app.EnsembleExtractor], ensemble)
+		log.Printf("ensemble of %v using %s", ensembleCfg.Weights, ensembleCfg.Method)
+	}
+	if _, err := extractors.Default(); err != nil && !skipped[cfg.Extractor.Algorithm] {
+		log.Fatalf("default tag extractor is not available: %v", err)
+	} else if err != nil {
+		log.Printf("warning: the default %s tag extractor is skipped, available: %v", cfg.Extractor.Algorithm, extractors.Names())
+	} else {
+		log.Printf("using %s tag extractor by default, available: %v", cfg.Extractor.Algorithm, extractors.Names())
+	}
+
+	// detect named entities before the extractors lowercase the text
+	if cfg.Entities.Enabled {
+		extractors.Wrap(app.EntityDecorator, app.DecoratorVersions[app.EntityDecorator], func(tagExtractor port.TagExtractor) port.TagExtractor {
+			return app.NewEntityExtractorService(tagExtractor, cfg.Entities)
+		})
+		log.Printf("detecting named entities (boost: %v)", cfg.Entities.Boost)
+	}
+
+	// link entities to the local gazetteers
+	if len(cfg.Gazetteer.Paths) > 0 {
+		gazetteer, err := app.LoadGazetteer(cfg.Gazetteer.Paths)
+		if err != nil {
+			log.Fatalf("failed to load gazetteer: %v", err)
+		}
+		extractors.Wrap(app.GazetteerDecorator, app.DecoratorVersions[app.GazetteerDecorator], func(tagExtractor port.TagExtractor) port.TagExtractor {
+			return app.NewGazetteerExtractorService(tagExtractor, gazetteer, cfg.Gazetteer)
+		})
+		log.Printf("linking entities to %d gazetteer entries", gazetteer.Size())
+	}
+
+	// map tags onto the controlled vocabulary; all extractors share one
+	// vocabulary snapshot
+	if cfg.Taxonomy.Enabled && sources.vocabulary == nil {
+		log.Printf("warning: no vocabulary, tags are not mapped onto the controlled vocabulary")
+	} else if cfg.Taxonomy.Enabled {
+		taxonomy := app.NewTaxonomyExtractorService(nil, sources.vocabulary, cfg.Taxonomy)
+		go taxonomy.StartRefresh(ctx, cfg.Taxonomy.RefreshInterval)
+		extractors.Wrap(app.TaxonomyDecorator, app.DecoratorVersions[app.TaxonomyDecorator], func(tagExtractor port.TagExtractor) port.TagExtractor {
+			return taxonomy.Wrap(tagExtractor)
+		})
+		log.Printf("using controlled vocabulary (enforce: %v)", cfg.Taxonomy.Enforce)
+	}
+
+	// weight tags by what editors accepted and rejected per source; all
+	// extractors share one snapshot of the learned weights
+	if cfg.Feedback.Enabled && sources.feedback == nil {
+		log.Printf("warning: no tag feedback, tags are not weighted by editor decisions")
+	} else if cfg.Feedback.Enabled {
+		feedback := app.NewFeedbackExtractorService(nil, sources.feedback, cfg.Feedback)
+		go feedback.StartRefresh(ctx, cfg.Feedback.RefreshInterval)
+		extractors.Wrap(app.FeedbackDecorator, app.DecoratorVersions[app.FeedbackDecorator], func(tagExtractor port.TagExtractor) port.TagExtractor {
+			return feedback.Wrap(tagExtractor)
+		})
+		log.Printf("learning from tag feedback (strength: %v)", cfg.Feedback.Strength)
+	}
+
+	return extractors
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/SaeedMPro/article-tag-extractor/internal/config"
 	"github.com/SaeedMPro/article-tag-extractor/internal/infra/grpc"
 	"github.com/SaeedMPro/article-tag-extractor/internal/infra/mongodb"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
 )
 
 func main() {
@@ -21,6 +22,48 @@ func main() {
 	cfg := config.LoadConfig()
 	log.Printf("config loaded: %v", cfg)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// load stop words and reload them on SIGHUP or when the files change
+	if len(cfg.StopWords.Paths) > 0 {
+		stopWordReloader := app.NewStopWordReloader(cfg.StopWords)
+		if err := stopWordReloader.Reload(); err != nil {
+			log.Fatalf("failed to load stop words: %v", err)
+		}
+		if cfg.StopWords.ReloadInterval > 0 {
+			go stopWordReloader.Watch(ctx, cfg.StopWords.ReloadInterval)
+		}
+
+		hangup := make(chan os.Signal, 1)
+		signal.Notify(hangup, syscall.SIGHUP)
+		go func() {
+			for range hangup {
+				if err := stopWordReloader.Reload(); err != nil {
+					log.Printf("failed to reload stop words: %v", err)
+				}
+			}
+		}()
+	}
+
+	// the extractors fall back to the built-in patterns, so fail early instead
+	if cfg.Extractor.ProtectedTokens {
+		if _, err := utils.NewProtectedTokenizer(cfg.Extractor.ProtectedPatternsFile); err != nil {
+			log.Fatalf("failed to load protected patterns: %v", err)
+		}
+	}
+
+	// "eval" scores the extractors on a labeled corpus instead of serving; it
+	// reads no stored data, so extractors and decorators that need it are
+	// skipped
+	if len(os.Args) > 1 && os.Args[1] == "eval" {
+		extractors := newExtractors(ctx, cfg, extractorSources{})
+		if err := eval(cfg, extractors, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("failed to evaluate tag extractors: %v", err)
+		}
+		return
+	}
+
 	// connect to mongo
 	db, err := mongodb.NewClient(cfg.Database)
 	if err != nil {
@@ -36,7 +79,80 @@ func main() {
 
 	// create repo & service & grpc server
 	articleRepo := mongodb.NewArticleRepository(db.Conn, cfg.Database.DBName, "articles")
-	articleService := app.NewArticleService(articleRepo)
+
+	// "train" writes the category model instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "train" {
+		if err := train(ctx, cfg, articleRepo, os.Args[2:]); err != nil {
+			log.Fatalf("failed to train category classifier: %v", err)
+		}
+		return
+	}
+
+	// "vocabulary" imports the controlled vocabulary instead of serving
+	vocabularyRepo := mongodb.NewVocabularyRepository(db.Conn, cfg.Database.DBName, "articles")
+	if len(os.Args) > 1 && os.Args[1] == "vocabulary" {
+		if err := vocabulary(ctx, vocabularyRepo, os.Args[2:]); err != nil {
+			log.Fatalf("failed to import vocabulary: %v", err)
+		}
+		return
+	}
+
+	// "topics" fits the topic model to the stored articles instead of serving
+	topicRepo := mongodb.NewTopicRepository(db.Conn, cfg.Database.DBName, "articles")
+	if len(os.Args) > 1 && os.Args[1] == "topics" {
+		if err := topics(ctx, cfg, topicRepo, os.Args[2:]); err != nil {
+			log.Fatalf("failed to model topics: %v", err)
+		}
+		return
+	}
+
+	// register the extractors and decorators, reading their data from mongo
+	var feedbackRepo *mongodb.TagFeedbackRepository
+	sources := extractorSources{documentFrequencies: articleRepo}
+	if cfg.Taxonomy.Enabled {
+		sources.vocabulary = vocabularyRepo
+	}
+	if cfg.Feedback.Enabled {
+		feedbackRepo = mongodb.NewTagFeedbackRepository(db.Conn, cfg.Database.DBName, "articles")
+		sources.feedback = feedbackRepo
+	}
+	extractors := newExtractors(ctx, cfg, sources)
+
+	articleService := app.NewArticleServiceWithRegistry(articleRepo, extractors)
+	articleService.Limits = app.NewTagLimits(cfg.Extractor)
+	if err := articleService.Limits.Validate(); err != nil {
+		log.Fatalf("invalid tag limits: %v", err)
+	}
+	if feedbackRepo != nil {
+		articleService.Feedback = feedbackRepo
+	}
+	articleService.Topics = topicRepo
+
+	// filter tags through the configured policies
+	if cfg.Policy.File != "" {
+		tagPolicies, err := app.LoadTagPolicies(cfg.Policy.File)
+		if err != nil {
+			log.Fatalf("failed to load tag policies: %v", err)
+		}
+		articleService.TagPolicies = tagPolicies
+		log.Printf("tag policies loaded from %s", cfg.Policy.File)
+	}
+
+	// assign categories with the trained model
+	if cfg.Classifier.ModelFile != "" {
+		classifier, err := app.LoadCategoryClassifier(cfg.Classifier, cfg.Extractor)
+		if err != nil {
+			log.Fatalf("failed to load category classifier: %v", err)
+		}
+		articleService.Classifier = classifier
+		log.Printf("classifying articles into %v", classifier.Categories())
+	}
+
+	// summarize every processed article
+	if cfg.Summarizer.Sentences > 0 {
+		articleService.Summarizer = app.NewSummarizer(cfg.Summarizer, cfg.Extractor)
+		log.Printf("summarizing articles in %d sentences", cfg.Summarizer.Sentences)
+	}
 	grpcServer := grpc.NewServer(articleService)
 
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Server.GRPCPort))
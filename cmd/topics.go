@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/app"
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// topics fits the topic model to the stored articles, saves its topics and
+// assigns every article its topic mixture.
+func topics(ctx context.Context, cfg *config.Config, repo port.TopicModelRepository, args []string) error {
+	topicsCfg := cfg.Topics
+	flags := flag.NewFlagSet("topics", flag.ContinueOnError)
+	flags.IntVar(&topicsCfg.Count, "count", topicsCfg.Count, "number of topics (default $TOPICS_COUNT)")
+	flags.IntVar(&topicsCfg.Iterations, "iterations", topicsCfg.Iterations, "Gibbs sampling sweeps (default $TOPICS_ITERATIONS)")
+	flags.IntVar(&topicsCfg.Seed, "seed", topicsCfg.Seed, "random seed (default $TOPICS_SEED)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	model, err := app.TrainTopicModel(ctx, repo, topicsCfg, cfg.Extractor)
+	if err != nil {
+		return err
+	}
+	if err := model.Save(ctx, repo); err != nil {
+		return err
+	}
+
+	log.Printf("modeled %d topics on %d articles (%d words)", len(model.Topics), model.Documents, model.Vocabulary)
+	for _, topic := range model.Topics {
+		words := make([]string, len(topic.Words))
+		for i, word := range topic.Words {
+			words[i] = word.Word
+		}
+		log.Printf("topic %d (%.1f%%, %d articles): %s", topic.ID, 100*topic.Weight, topic.Articles, strings.Join(words, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/app"
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// train fits the category classifier on the stored articles that have
+// categories and writes the model file.
+func train(ctx context.Context, cfg *config.Config, repo port.CategorizedArticleRepository, args []string) error {
+	flags := flag.NewFlagSet("train", flag.ContinueOnError)
+	out := flags.String("out", cfg.Classifier.ModelFile, "model file to write (default $CLASSIFIER_MODEL_FILE)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("no model file: pass -out or set CLASSIFIER_MODEL_FILE")
+	}
+
+	model, err := app.TrainCategoryModel(ctx, repo, cfg.Extractor)
+	if err != nil {
+		return err
+	}
+	if err := model.Save(*out); err != nil {
+		return err
+	}
+
+	log.Printf("trained %d categories on %d articles (%d words), model written to %s",
+		len(model.Categories), model.Documents, model.Vocabulary, *out)
+	return nil
+}
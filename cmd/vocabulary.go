@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/app"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// vocabulary imports the controlled vocabulary terms of a JSONL file into
+// repo, replacing the aliases of terms it already holds.
+func vocabulary(ctx context.Context, repo port.VocabularyRepository, args []string) error {
+	flags := flag.NewFlagSet("vocabulary", flag.ContinueOnError)
+	file := flags.String("file", "", `JSONL file of terms, e.g. {"tag": "go", "aliases": ["golang"]}`)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("no vocabulary file: pass -file")
+	}
+
+	terms, err := app.LoadVocabulary(*file)
+	if err != nil {
+		return err
+	}
+	if err := app.ImportVocabulary(ctx, repo, terms); err != nil {
+		return err
+	}
+
+	log.Printf("imported %d vocabulary terms from %s", len(terms), *file)
+	return nil
+}
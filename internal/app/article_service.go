@@ -2,16 +2,54 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
 	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
 )
 
 type ArticleService struct {
 	Repo         port.ArticleRepository
 	TagExtractor port.TagExtractor
+	// Extractors selects the extractor of a request by name and takes
+	// precedence over TagExtractor; nil only allows TagExtractor
+	Extractors *ExtractorRegistry
+	// TagPolicies filters extracted tags before they are saved; nil accepts
+	// every tag
+	TagPolicies *TagPolicies
+	// Limits bounds the tags saved on an article unless a request overrides
+	// them
+	Limits TagLimits
+	// Classifier assigns categories to articles; nil assigns none
+	Classifier *CategoryClassifier
+	// Feedback stores editor decisions on tags; nil disables feedback
+	Feedback port.TagFeedbackRepository
+	// Summarizer picks the key sentences of articles; nil disables summaries
+	Summarizer *Summarizer
+	// Topics reads the topics of the topic model; nil disables topics
+	Topics port.TopicRepository
+}
+
+// ProcessOptions are the per-request settings of ProcessArticlesWithOptions.
+// Zero values select the defaults.
+type ProcessOptions struct {
+	// Extractor names a registered extractor; empty selects the default
+	Extractor string
+	// MaxTags, MinFrequency and MinScore override the limits of the
+	// service, see TagLimits
+	MaxTags      int
+	MinFrequency int
+	MinScore     float64
+	// Explain stores the explanation of every tag on the article
+	Explain bool
+	// SummarySentences overrides the summary length of the summarizer
+	SummarySentences int
 }
 
 func NewArticleService(repo port.ArticleRepository) *ArticleService {
@@ -28,7 +66,32 @@ func NewArticleServiceWithExtractor(repo port.ArticleRepository, tagExtractor po
 	}
 }
 
+// NewArticleServiceWithRegistry returns a service that lets each request pick
+// one of extractors.
+func NewArticleServiceWithRegistry(repo port.ArticleRepository, extractors *ExtractorRegistry) *ArticleService {
+	s := &ArticleService{
+		Repo:       repo,
+		Extractors: extractors,
+	}
+	if registered, err := extractors.Default(); err == nil {
+		s.TagExtractor = registered.Extractor
+	}
+	return s
+}
+
 func (s *ArticleService) ProcessArticles(ctx context.Context, articles []*entity.Article) (int, error) {
+	return s.ProcessArticlesWithOptions(ctx, articles, ProcessOptions{})
+}
+
+// ProcessArticlesWithOptions tags and saves articles like ProcessArticles
+// with the extractor and limits of opts. It fails without processing anything
+// if the extractor is unknown.
+func (s *ArticleService) ProcessArticlesWithOptions(ctx context.Context, articles []*entity.Article, opts ProcessOptions) (int, error) {
+	extractor, err := s.Extractor(opts.Extractor)
+	if err != nil {
+		return 0, err
+	}
+
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	count := 0
@@ -39,17 +102,29 @@ func (s *ArticleService) ProcessArticles(ctx context.Context, articles []*entity
 		go func(a *entity.Article) {
 			defer wg.Done()
 			
-			tags := s.TagExtractor.ExtractTags(a.Title, a.Body)
-			a.Tags = tags
+			s.tagArticle(a, extractor, opts)
 
 			article := &entity.Article{
-				Title:     a.Title,
-				Body:      a.Body,
-				Tags:      tags,
-				CreatedAt: time.Now(),
+				Title:               a.Title,
+				Body:                a.Body,
+				ContentType:         a.ContentType,
+				Language:            a.Language,
+				Tenant:              a.Tenant,
+				Source:              a.Source,
+				Tags:                a.Tags,
+				RejectedTags:        a.RejectedTags,
+				Entities:            a.Entities,
+				Categories:          a.Categories,
+				PredictedCategories: a.PredictedCategories,
+				Extractor:           a.Extractor,
+				ExtractorVersion:    a.ExtractorVersion,
+				Summary:             a.Summary,
+				Explanations:        a.Explanations,
+				CreatedAt:           time.Now(),
 			}
 
 			if err := s.Repo.SaveArticle(ctx, article); err == nil {
+				a.ID = article.ID
 				mu.Lock()
 				count++
 				mu.Unlock()
@@ -61,6 +136,266 @@ func (s *ArticleService) ProcessArticles(ctx context.Context, articles []*entity
 	return count, nil
 }
 
+// ExplainTags tags article like ProcessArticlesWithOptions without saving it
+// and returns the explanation of every tag, in the order of its tags.
+func (s *ArticleService) ExplainTags(ctx context.Context, article *entity.Article, opts ProcessOptions) ([]entity.TagExplanation, error) {
+	extractor, err := s.Extractor(opts.Extractor)
+	if err != nil {
+		return nil, err
+	}
+	opts.Explain = true
+	s.tagArticle(article, extractor, opts)
+	return article.Explanations, nil
+}
+
+// ErrSummarizerDisabled is returned for summaries when no summarizer is
+// configured.
+var ErrSummarizerDisabled = errors.New("summarizer is disabled")
+
+// Summarize sets the language and summary of article without saving it and
+// returns the sentences of the summary. sentences <= 0 selects the configured
+// length.
+func (s *ArticleService) Summarize(ctx context.Context, article *entity.Article, sentences int) ([]entity.SummarySentence, error) {
+	if s.Summarizer == nil {
+		return nil, ErrSummarizerDisabled
+	}
+	doc := articleDocument(article)
+	article.Language = doc.Language
+
+	summary := s.Summarizer.Summarize(doc, sentences)
+	article.Summary = summaryText(summary)
+	return summary, nil
+}
+
+// tagArticle extracts the tags of a with extractor within the limits of opts
+// and sets its language, tags, entities, extractor, predicted categories and
+// summary, and with opts.Explain its explanations.
+func (s *ArticleService) tagArticle(a *entity.Article, extractor RegisteredExtractor, opts ProcessOptions) {
+	doc := articleDocument(a)
+	a.Language = doc.Language
+	limits := s.limits(opts)
+
+	scoredTags := limits.Filter(extractor.Extractor.ExtractScoredTags(doc))
+	tags, rejected := s.TagPolicies.Select(a.Tenant, a.Source).SelectTags(scoredTags, limits.maxTags())
+	a.Tags = tags
+	a.RejectedTags = rejected
+	a.Entities = tagEntities(scoredTags, tags)
+	a.Extractor = extractor.Name
+	a.ExtractorVersion = extractor.Version
+	a.PredictedCategories = s.Classifier.Classify(doc)
+	a.Summary = summaryText(s.Summarizer.Summarize(doc, opts.SummarySentences))
+
+	if opts.Explain {
+		a.Explanations = explain(extractor.Extractor, doc, selectedTags(scoredTags, tags))
+	}
+}
+
+// articleDocument strips the markup of a and detects its language.
+func articleDocument(a *entity.Article) entity.Document {
+	title := utils.PlainText(a.ContentType, a.Title)
+	body := utils.ExtractText(a.ContentType, a.Body)
+	headings := strings.Join(body.Headings, "\n\n")
+
+	return entity.Document{
+		Title:    title,
+		Body:     body.Text,
+		Headings: headings,
+		Language: utils.DetectLanguage(title + " " + headings + " " + body.Text),
+		Tenant:   a.Tenant,
+		Source:   a.Source,
+	}
+}
+
+// limits returns the limits of the service overridden by those of opts.
+func (s *ArticleService) limits(opts ProcessOptions) TagLimits {
+	return s.Limits.Override(TagLimits{
+		MaxTags:      opts.MaxTags,
+		MinFrequency: opts.MinFrequency,
+		MinScore:     opts.MinScore,
+	})
+}
+
+// selectedTags returns the scored tags of tags, in the order of tags.
+func selectedTags(scoredTags []entity.ScoredTag, tags []string) []entity.ScoredTag {
+	scored := make(map[string]entity.ScoredTag, len(scoredTags))
+	for _, st := range scoredTags {
+		if _, ok := scored[st.Tag]; !ok {
+			scored[st.Tag] = st
+		}
+	}
+
+	selected := make([]entity.ScoredTag, 0, len(tags))
+	for _, tag := range tags {
+		st, ok := scored[tag]
+		if !ok {
+			st = entity.ScoredTag{Tag: tag}
+		}
+		selected = append(selected, st)
+	}
+	return selected
+}
+
+// Extractor returns the extractor registered under name, or the default one
+// for an empty name.
+func (s *ArticleService) Extractor(name string) (RegisteredExtractor, error) {
+	if s.Extractors != nil {
+		return s.Extractors.Get(name)
+	}
+	if name != "" {
+		return RegisteredExtractor{}, fmt.Errorf("%w: %s", ErrUnknownExtractor, name)
+	}
+	return RegisteredExtractor{Extractor: s.TagExtractor}, nil
+}
+
+// tagEntities returns the entity type and ID of the selected tags that name
+// an entity.
+func tagEntities(scoredTags []entity.ScoredTag, tags []string) []entity.TagEntity {
+	entities := make(map[string]entity.TagEntity)
+	for _, st := range scoredTags {
+		if _, ok := entities[st.Tag]; !ok && st.Type != "" {
+			entities[st.Tag] = entity.TagEntity{Tag: st.Tag, Type: st.Type, ID: st.ID}
+		}
+	}
+
+	var selected []entity.TagEntity
+	for _, tag := range tags {
+		if e, ok := entities[tag]; ok {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// ErrFeedbackDisabled is returned for feedback when no feedback repository is
+// configured.
+var ErrFeedbackDisabled = errors.New("tag feedback is disabled")
+
+// ErrConflictingFeedback is returned for feedback that both keeps and
+// rejects a tag.
+var ErrConflictingFeedback = errors.New("tag cannot be both kept and rejected")
+
+// SubmitTagFeedback records the decisions of an editor on the tags of the
+// stored article articleID: rejected tags are removed from it and added tags
+// appended. It returns the article with its new tags. Tags are compared in
+// their normalized form, so rejecting "Golang" conflicts with accepting
+// "golang".
+func (s *ArticleService) SubmitTagFeedback(ctx context.Context, articleID string, accept, reject, add []string) (*entity.Article, error) {
+	if s.Feedback == nil {
+		return nil, ErrFeedbackDisabled
+	}
+	rejected := make(map[string]bool, len(reject))
+	for _, tag := range reject {
+		rejected[normalizeTag(tag)] = true
+	}
+	for _, tag := range slices.Concat(accept, add) {
+		if key := normalizeTag(tag); key != "" && rejected[key] {
+			return nil, fmt.Errorf("%w: %q", ErrConflictingFeedback, tag)
+		}
+	}
+
+	article, err := s.Feedback.GetArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var feedback []entity.TagFeedback
+	for _, decisions := range []struct {
+		action string
+		tags   []string
+	}{
+		{entity.FeedbackAccept, accept},
+		{entity.FeedbackReject, reject},
+		{entity.FeedbackAdd, add},
+	} {
+		for _, tag := range decisions.tags {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				feedback = append(feedback, entity.TagFeedback{
+					ArticleID: articleID,
+					Source:    article.Source,
+					Tag:       tag,
+					Action:    decisions.action,
+					CreatedAt: now,
+				})
+			}
+		}
+	}
+	if len(feedback) == 0 {
+		return article, nil
+	}
+	if err := s.Feedback.SaveTagFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+
+	tags := feedbackTags(article.Tags, feedback)
+	if !slices.Equal(tags, article.Tags) {
+		if err := s.Feedback.UpdateArticleTags(ctx, articleID, tags); err != nil {
+			return nil, err
+		}
+		article.Tags = tags
+	}
+	return article, nil
+}
+
+// feedbackTags returns tags without the rejected tags of feedback and with
+// the added ones that are missing appended.
+func feedbackTags(tags []string, feedback []entity.TagFeedback) []string {
+	rejected := make(map[string]bool)
+	for _, f := range feedback {
+		if f.Action == entity.FeedbackReject {
+			rejected[normalizeTag(f.Tag)] = true
+		}
+	}
+
+	result := []string{}
+	present := make(map[string]bool)
+	for _, tag := range tags {
+		if key := normalizeTag(tag); !rejected[key] && !present[key] {
+			result = append(result, tag)
+			present[key] = true
+		}
+	}
+	for _, f := range feedback {
+		if key := normalizeTag(f.Tag); f.Action == entity.FeedbackAdd && !present[key] {
+			result = append(result, f.Tag)
+			present[key] = true
+		}
+	}
+	return result
+}
+
+// ErrTopicsDisabled is returned for topics when no topic repository is
+// configured.
+var ErrTopicsDisabled = errors.New("topics are disabled")
+
+// ListTopics returns the topics of the topic model with at most words of
+// their most probable words each; words <= 0 keeps every stored word. There
+// are no topics until the topics command has run.
+func (s *ArticleService) ListTopics(ctx context.Context, words int) ([]entity.Topic, error) {
+	if s.Topics == nil {
+		return nil, ErrTopicsDisabled
+	}
+	topics, err := s.Topics.GetTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if words > 0 {
+		for i := range topics {
+			topics[i].Words = topics[i].Words[:min(words, len(topics[i].Words))]
+		}
+	}
+	return topics, nil
+}
+
+// GetArticlesByTopic returns up to limit articles assigned to topic, those
+// it weighs most in first.
+func (s *ArticleService) GetArticlesByTopic(ctx context.Context, topic, limit int) ([]*entity.Article, error) {
+	if s.Topics == nil {
+		return nil, ErrTopicsDisabled
+	}
+	return s.Topics.GetArticlesByTopic(ctx, topic, limit)
+}
+
 func (s *ArticleService) GetTopTags(ctx context.Context, limit int) ([]entity.TagFrequency, error) {
 	return s.Repo.GetTopTags(ctx, limit)
 }
@@ -49,10 +49,18 @@ type MockTagExtractor struct {
 	tags []string
 }
 
-func (m *MockTagExtractor) ExtractTags(title, body string) []string {
+func (m *MockTagExtractor) ExtractTags(doc entity.Document) []string {
 	return m.tags
 }
 
+func (m *MockTagExtractor) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	scoredTags := make([]entity.ScoredTag, 0, len(m.tags))
+	for i, tag := range m.tags {
+		scoredTags = append(scoredTags, entity.ScoredTag{Tag: tag, Score: float64(len(m.tags) - i)})
+	}
+	return scoredTags
+}
+
 func TestArticleService_ProcessArticles(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -176,6 +184,93 @@ func TestArticleService_ProcessArticles(t *testing.T) {
 	}
 }
 
+func TestArticleService_ProcessArticles_Language(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	service := NewArticleServiceWithExtractor(mockRepo, &MockTagExtractor{tags: []string{"energie"}})
+
+	articles := []*entity.Article{
+		{Title: "Die Zukunft der Energie", Body: "Die Regierung hat einen Plan, und die Bürger wollen, dass die Preise sinken."},
+	}
+
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(mockRepo.articles) != 1 {
+		t.Fatalf("Expected 1 saved article, got %d", len(mockRepo.articles))
+	}
+	if mockRepo.articles[0].Language != "de" {
+		t.Errorf("Expected language 'de', got '%s'", mockRepo.articles[0].Language)
+	}
+	if articles[0].Language != "de" {
+		t.Errorf("Expected input article language 'de', got '%s'", articles[0].Language)
+	}
+}
+
+// recordingTagExtractor records the documents it is asked to tag
+type recordingTagExtractor struct {
+	MockTagExtractor
+	mu   sync.Mutex
+	docs []entity.Document
+}
+
+func (r *recordingTagExtractor) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.docs = append(r.docs, doc)
+	return r.MockTagExtractor.ExtractScoredTags(doc)
+}
+
+func TestArticleService_ProcessArticles_Document(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	extractor := &recordingTagExtractor{MockTagExtractor: MockTagExtractor{tags: []string{"energie"}}}
+	service := NewArticleServiceWithExtractor(mockRepo, extractor)
+
+	articles := []*entity.Article{
+		{Title: "Die Zukunft der Energie", Body: "Die Regierung hat einen Plan, und die Bürger wollen, dass die Preise sinken.", Tenant: "acme"},
+	}
+
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := entity.Document{Title: articles[0].Title, Body: articles[0].Body, Language: "de", Tenant: "acme"}
+	if len(extractor.docs) != 1 || extractor.docs[0] != expected {
+		t.Errorf("Expected extractor to receive %v, got %v", expected, extractor.docs)
+	}
+	if len(mockRepo.articles) != 1 || mockRepo.articles[0].Tenant != "acme" {
+		t.Errorf("Expected the tenant to be saved, got %v", mockRepo.articles)
+	}
+}
+
+func TestArticleService_ProcessArticles_Markup(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	extractor := &recordingTagExtractor{MockTagExtractor: MockTagExtractor{tags: []string{"rust"}}}
+	service := NewArticleServiceWithExtractor(mockRepo, extractor)
+
+	body := "<h2>Memory safety</h2><div><p>Rust&nbsp;prevents data races.</p><script>var href;</script></div>"
+	articles := []*entity.Article{
+		{Title: "Rust &amp; Go", Body: body, ContentType: "text/html"},
+	}
+
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(extractor.docs) != 1 {
+		t.Fatalf("Expected 1 extracted document, got %d", len(extractor.docs))
+	}
+	doc := extractor.docs[0]
+	if doc.Title != "Rust & Go" || doc.Headings != "Memory safety" || doc.Body != "Rust prevents data races." {
+		t.Errorf("Expected markup to be stripped before extraction, got %+v", doc)
+	}
+
+	// the article is stored as it was received
+	if len(mockRepo.articles) != 1 || mockRepo.articles[0].Body != body || mockRepo.articles[0].ContentType != "text/html" {
+		t.Errorf("Expected the original body and content type to be saved, got %+v", mockRepo.articles)
+	}
+}
+
 func TestArticleService_ProcessArticles_Concurrency(t *testing.T) {
 	// Test concurrent processing with timing
 	mockRepo := &MockArticleRepository{}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// categoryModelVersion is bumped whenever the model file format changes.
+const categoryModelVersion = 1
+
+// ErrNoTrainingData is returned when no stored article has categories.
+var ErrNoTrainingData = errors.New("no articles with categories to train on")
+
+// CategoryModel holds the word counts of a multinomial Naive Bayes category
+// classifier. It is trained offline and stored as JSON. Stemming and
+// ProtectedTokens record how words were analyzed, since classifying must
+// analyze them the same way.
+type CategoryModel struct {
+	Version         int                       `json:"version"`
+	TrainedAt       time.Time                 `json:"trained_at"`
+	Stemming        bool                      `json:"stemming"`
+	ProtectedTokens bool                      `json:"protected_tokens"`
+	Documents       int                       `json:"documents"`
+	Vocabulary      int                       `json:"vocabulary"`
+	Categories      map[string]*CategoryStats `json:"categories"`
+}
+
+// CategoryStats holds the number of training documents of a category and
+// how often each word occurs in them.
+type CategoryStats struct {
+	Documents int            `json:"documents"`
+	Words     int            `json:"words"`
+	Counts    map[string]int `json:"counts"`
+}
+
+// CategoryTrainer counts the words of categorized articles into a model.
+type CategoryTrainer struct {
+	analyzer   analyzer
+	model      *CategoryModel
+	vocabulary map[string]bool
+}
+
+// NewCategoryTrainer returns a trainer analyzing words like the extractors
+// configured by cfg.
+func NewCategoryTrainer(cfg config.Extractor) *CategoryTrainer {
+	return &CategoryTrainer{
+		analyzer: newAnalyzer(cfg),
+		model: &CategoryModel{
+			Version:         categoryModelVersion,
+			Stemming:        cfg.Stemming,
+			ProtectedTokens: cfg.ProtectedTokens,
+			Categories:      make(map[string]*CategoryStats),
+		},
+		vocabulary: make(map[string]bool),
+	}
+}
+
+// Add counts the words of article towards each of its categories. Articles
+// without categories are skipped.
+func (t *CategoryTrainer) Add(article *entity.Article) {
+	if len(article.Categories) == 0 {
+		return
+	}
+
+	counts := documentWords(articleDocument(article), t.analyzer)
+	t.model.Documents++
+	for _, category := range article.Categories {
+		stats, ok := t.model.Categories[category]
+		if !ok {
+			stats = &CategoryStats{Counts: make(map[string]int)}
+			t.model.Categories[category] = stats
+		}
+		stats.Documents++
+		for word, n := range counts {
+			stats.Counts[word] += n
+			stats.Words += n
+		}
+	}
+	for word := range counts {
+		t.vocabulary[word] = true
+	}
+}
+
+// Model returns the model trained so far.
+func (t *CategoryTrainer) Model() *CategoryModel {
+	t.model.Vocabulary = len(t.vocabulary)
+	t.model.TrainedAt = time.Now()
+	return t.model
+}
+
+// TrainCategoryModel trains a model on every stored article that has
+// categories.
+func TrainCategoryModel(ctx context.Context, repo port.CategorizedArticleRepository, cfg config.Extractor) (*CategoryModel, error) {
+	trainer := NewCategoryTrainer(cfg)
+	err := repo.ForEachCategorizedArticle(ctx, func(article *entity.Article) error {
+		trainer.Add(article)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	model := trainer.Model()
+	if model.Documents == 0 {
+		return nil, ErrNoTrainingData
+	}
+	return model, nil
+}
+
+// Save writes the model to path as JSON.
+func (m *CategoryModel) Save(path string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadCategoryModel reads a model written by Save.
+func LoadCategoryModel(path string) (*CategoryModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var model CategoryModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("parse category model %s: %w", path, err)
+	}
+	if model.Version != categoryModelVersion {
+		return nil, fmt.Errorf("category model %s has version %d, expected %d", path, model.Version, categoryModelVersion)
+	}
+	if len(model.Categories) == 0 {
+		return nil, fmt.Errorf("category model %s has no categories", path)
+	}
+	return &model, nil
+}
+
+// CategoryClassifier assigns the categories of a model to documents.
+type CategoryClassifier struct {
+	model          *CategoryModel
+	analyzer       analyzer
+	categories     []string
+	alpha          float64
+	minProbability float64
+	maxCategories  int
+}
+
+// NewCategoryClassifier returns a classifier for model. Words are analyzed
+// with the stemming and protected tokens the model was trained with and the
+// protected patterns of extractor.
+func NewCategoryClassifier(model *CategoryModel, cfg config.Classifier, extractor config.Extractor) *CategoryClassifier {
+	if cfg.Alpha <= 0 {
+		cfg.Alpha = 1
+	}
+	if cfg.MaxCategories <= 0 {
+		cfg.MaxCategories = 1
+	}
+	extractor.Stemming = model.Stemming
+	extractor.ProtectedTokens = model.ProtectedTokens
+
+	categories := make([]string, 0, len(model.Categories))
+	for category := range model.Categories {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	return &CategoryClassifier{
+		model:          model,
+		analyzer:       newAnalyzer(extractor),
+		categories:     categories,
+		alpha:          cfg.Alpha,
+		minProbability: cfg.MinProbability,
+		maxCategories:  cfg.MaxCategories,
+	}
+}
+
+// LoadCategoryClassifier loads the model file of cfg.
+func LoadCategoryClassifier(cfg config.Classifier, extractor config.Extractor) (*CategoryClassifier, error) {
+	model, err := LoadCategoryModel(cfg.ModelFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewCategoryClassifier(model, cfg, extractor), nil
+}
+
+// Categories returns the categories of the model in alphabetical order.
+func (c *CategoryClassifier) Categories() []string {
+	return c.categories
+}
+
+// Classify returns the most probable categories of doc, most probable
+// first. A nil classifier assigns none.
+func (c *CategoryClassifier) Classify(doc entity.Document) []entity.CategoryScore {
+	if c == nil {
+		return nil
+	}
+
+	var selected []entity.CategoryScore
+	for _, cs := range c.Probabilities(doc) {
+		if len(selected) == c.maxCategories || cs.Probability < c.minProbability {
+			break
+		}
+		selected = append(selected, cs)
+	}
+	return selected
+}
+
+// Probabilities returns the probability of every category of the model for
+// doc, most probable first. Words the model has never seen are ignored.
+func (c *CategoryClassifier) Probabilities(doc entity.Document) []entity.CategoryScore {
+	counts := documentWords(doc, c.analyzer)
+	vocabulary := float64(c.model.Vocabulary)
+
+	logProbabilities := make([]float64, len(c.categories))
+	for i, category := range c.categories {
+		stats := c.model.Categories[category]
+		logP := math.Log(float64(stats.Documents) / float64(c.model.Documents))
+		denominator := math.Log(float64(stats.Words) + c.alpha*vocabulary)
+		for word, n := range counts {
+			if !c.known(word) {
+				continue
+			}
+			logP += float64(n) * (math.Log(float64(stats.Counts[word])+c.alpha) - denominator)
+		}
+		logProbabilities[i] = logP
+	}
+
+	// normalize in log space so long documents do not underflow
+	top := math.Inf(-1)
+	for _, logP := range logProbabilities {
+		top = max(top, logP)
+	}
+	sum := 0.0
+	for _, logP := range logProbabilities {
+		sum += math.Exp(logP - top)
+	}
+
+	scores := make([]entity.CategoryScore, len(c.categories))
+	for i, category := range c.categories {
+		scores[i] = entity.CategoryScore{
+			Category:    category,
+			Probability: math.Exp(logProbabilities[i]-top) / sum,
+		}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Probability > scores[j].Probability
+	})
+	return scores
+}
+
+// known reports whether any category of the model has seen word.
+func (c *CategoryClassifier) known(word string) bool {
+	for _, stats := range c.model.Categories {
+		if stats.Counts[word] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// documentWords counts the words of doc that are not stop words, keyed the
+// way the extractors key terms.
+func documentWords(doc entity.Document, a analyzer) map[string]int {
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	counts := make(map[string]int)
+	for _, f := range a.weights.fields(doc) {
+		for _, token := range a.tokens(language, f.text) {
+			if !a.isStopWord(stopWords, doc.Tenant, language, token) {
+				counts[a.key(language, token)]++
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// MockCategorizedArticleRepository returns fixed articles
+type MockCategorizedArticleRepository struct {
+	articles []*entity.Article
+	err      error
+}
+
+func (m *MockCategorizedArticleRepository) ForEachCategorizedArticle(ctx context.Context, fn func(article *entity.Article) error) error {
+	if m.err != nil {
+		return m.err
+	}
+	for _, article := range m.articles {
+		if err := fn(article); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var trainingArticles = []*entity.Article{
+	{Title: "Striker scores twice", Body: "The striker scored two goals as the team won the league match.", Categories: []string{"sports"}},
+	{Title: "Coach praises defense", Body: "The coach said the team defended well and the goalkeeper saved a penalty.", Categories: []string{"sports"}},
+	{Title: "Final whistle", Body: "Fans cheered the team after the match ended with a late goal.", Categories: []string{"sports"}},
+	{Title: "New compiler release", Body: "The compiler release improves generics and speeds up builds of large programs.", Categories: []string{"technology"}},
+	{Title: "Database outage", Body: "Engineers restored the database cluster after a software bug corrupted an index.", Categories: []string{"technology"}},
+	{Title: "Chip makers", Body: "Chip makers expect software demand for servers and programs to grow.", Categories: []string{"technology", "business"}},
+	{Title: "Quarterly earnings", Body: "The company reported higher revenue and profit, and shares rose.", Categories: []string{"business"}},
+	{Title: "Uncategorized", Body: "This article has no categories and is skipped."},
+}
+
+func trainTestModel(t *testing.T) *CategoryModel {
+	t.Helper()
+	model, err := TrainCategoryModel(context.Background(), &MockCategorizedArticleRepository{articles: trainingArticles}, config.DefaultExtractor())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return model
+}
+
+func TestTrainCategoryModel(t *testing.T) {
+	model := trainTestModel(t)
+
+	if model.Documents != 7 {
+		t.Errorf("Expected 7 training documents, got %d", model.Documents)
+	}
+	documents := map[string]int{}
+	for category, stats := range model.Categories {
+		documents[category] = stats.Documents
+	}
+	if expected := map[string]int{"sports": 3, "technology": 3, "business": 2}; !reflect.DeepEqual(documents, expected) {
+		t.Errorf("Expected %v documents per category, got %v", expected, documents)
+	}
+	if model.Categories["sports"].Counts["team"] != 3 {
+		t.Errorf("Expected 'team' to occur 3 times in sports, got %d", model.Categories["sports"].Counts["team"])
+	}
+	if model.Categories["sports"].Counts["the"] != 0 {
+		t.Error("Expected stop words not to be counted")
+	}
+
+	if _, err := TrainCategoryModel(context.Background(), &MockCategorizedArticleRepository{}, config.DefaultExtractor()); !errors.Is(err, ErrNoTrainingData) {
+		t.Errorf("Expected ErrNoTrainingData, got %v", err)
+	}
+	repoErr := errors.New("connection refused")
+	if _, err := TrainCategoryModel(context.Background(), &MockCategorizedArticleRepository{err: repoErr}, config.DefaultExtractor()); !errors.Is(err, repoErr) {
+		t.Errorf("Expected the repository error, got %v", err)
+	}
+}
+
+func TestCategoryClassifier_Classify(t *testing.T) {
+	classifier := NewCategoryClassifier(trainTestModel(t), config.Classifier{MinProbability: 0.5, MaxCategories: 1}, config.DefaultExtractor())
+
+	tests := []struct {
+		name     string
+		doc      entity.Document
+		expected string
+	}{
+		{name: "sports", doc: entity.Document{Title: "Late goal", Body: "The team won the match with a penalty."}, expected: "sports"},
+		{name: "technology", doc: entity.Document{Title: "Compiler bug", Body: "A software bug in the compiler broke builds."}, expected: "technology"},
+		{name: "business", doc: entity.Document{Body: "Revenue and profit rose, lifting shares."}, expected: "business"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := classifier.Classify(tt.doc)
+			if len(categories) != 1 || categories[0].Category != tt.expected {
+				t.Errorf("Expected %s, got %v", tt.expected, categories)
+			}
+		})
+	}
+
+	// nothing the model knows leaves the priors, none of which is likely enough
+	if categories := classifier.Classify(entity.Document{Body: "Lorem ipsum dolor."}); len(categories) != 0 {
+		t.Errorf("Expected no categories, got %v", categories)
+	}
+
+	var none *CategoryClassifier
+	if categories := none.Classify(entity.Document{Body: "The team won."}); categories != nil {
+		t.Errorf("Expected a nil classifier to assign nothing, got %v", categories)
+	}
+}
+
+func TestCategoryClassifier_Probabilities(t *testing.T) {
+	classifier := NewCategoryClassifier(trainTestModel(t), config.Classifier{}, config.DefaultExtractor())
+
+	scores := classifier.Probabilities(entity.Document{Body: "Chip makers expect software demand for servers to grow, and revenue rose."})
+	if len(scores) != 3 {
+		t.Fatalf("Expected a probability per category, got %v", scores)
+	}
+	sum := 0.0
+	for i, cs := range scores {
+		sum += cs.Probability
+		if i > 0 && cs.Probability > scores[i-1].Probability {
+			t.Errorf("Expected categories ordered by probability, got %v", scores)
+		}
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("Expected probabilities to sum to 1, got %v", sum)
+	}
+}
+
+func TestCategoryModel_SaveLoad(t *testing.T) {
+	model := trainTestModel(t)
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := model.Save(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	classifier, err := LoadCategoryClassifier(config.Classifier{ModelFile: path}, config.DefaultExtractor())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if categories := classifier.Categories(); !reflect.DeepEqual(categories, []string{"business", "sports", "technology"}) {
+		t.Errorf("Expected the trained categories, got %v", categories)
+	}
+
+	model.Version = categoryModelVersion + 1
+	if err := model.Save(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := LoadCategoryModel(path); err == nil {
+		t.Error("Expected an error for another model version")
+	}
+}
+
+func TestArticleService_ProcessArticles_Categories(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	service := NewArticleService(mockRepo)
+	service.Classifier = NewCategoryClassifier(trainTestModel(t), config.Classifier{MinProbability: 0.5}, config.DefaultExtractor())
+
+	articles := []*entity.Article{{Title: "Late goal", Body: "The team won the match.", Categories: []string{"football"}}}
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	saved := mockRepo.articles[0]
+	if !reflect.DeepEqual(saved.Categories, []string{"football"}) {
+		t.Errorf("Expected the editor categories to be kept, got %v", saved.Categories)
+	}
+	if len(saved.PredictedCategories) != 1 || saved.PredictedCategories[0].Category != "sports" {
+		t.Errorf("Expected sports to be predicted, got %v", saved.PredictedCategories)
+	}
+}
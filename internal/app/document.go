@@ -0,0 +1,144 @@
+package app
+
+import (
+	"log"
+	"math"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// fieldWeights holds the weight of each document field and the half-life of
+// the early-position boost applied to body tokens.
+type fieldWeights struct {
+	title    float64
+	summary  float64
+	headings float64
+	body     float64
+	halfLife int
+}
+
+// newFieldWeights reads the weights of cfg. Weights that are not positive
+// fall back to the defaults.
+func newFieldWeights(cfg config.Extractor) fieldWeights {
+	defaults := config.DefaultExtractor()
+	if cfg.TitleWeight <= 0 {
+		cfg.TitleWeight = defaults.TitleWeight
+	}
+	if cfg.SummaryWeight <= 0 {
+		cfg.SummaryWeight = defaults.SummaryWeight
+	}
+	if cfg.HeadingWeight <= 0 {
+		cfg.HeadingWeight = defaults.HeadingWeight
+	}
+	if cfg.BodyWeight <= 0 {
+		cfg.BodyWeight = defaults.BodyWeight
+	}
+	return fieldWeights{
+		title:    cfg.TitleWeight,
+		summary:  cfg.SummaryWeight,
+		headings: cfg.HeadingWeight,
+		body:     cfg.BodyWeight,
+		halfLife: max(cfg.PositionHalfLife, 0),
+	}
+}
+
+// analyzer holds the settings that turn document fields into terms: field
+// weights, stemming and the tokenizer.
+type analyzer struct {
+	weights   fieldWeights
+	stemming  bool
+	tokenizer *utils.Tokenizer
+}
+
+// newAnalyzer reads the analysis settings of cfg. An unreadable or invalid
+// protected patterns file is logged and only the built-in patterns are used.
+func newAnalyzer(cfg config.Extractor) analyzer {
+	a := analyzer{weights: newFieldWeights(cfg), stemming: cfg.Stemming}
+	if !cfg.ProtectedTokens {
+		return a
+	}
+
+	tokenizer, err := utils.NewProtectedTokenizer(cfg.ProtectedPatternsFile)
+	if err != nil {
+		log.Printf("failed to load protected patterns, using the built-in set: %v", err)
+		tokenizer, _ = utils.NewProtectedTokenizer("")
+	}
+	a.tokenizer = tokenizer
+	return a
+}
+
+// tokens returns the tokens of text with the letters of language.
+func (a analyzer) tokens(language, text string) []utils.Token {
+	return utils.NormalizeTokens(language, a.tokenizer.Tokens(text))
+}
+
+// fragments returns the fragments of text with the letters of language.
+func (a analyzer) fragments(language, text string) [][]utils.Token {
+	fragments := a.tokenizer.Fragments(text)
+	for _, fragment := range fragments {
+		utils.NormalizeTokens(language, fragment)
+	}
+	return fragments
+}
+
+// isStopWord reports whether token is a stop word. Protected tokens never are.
+func (a analyzer) isStopWord(stopWords *utils.StopWords, tenant, language string, token utils.Token) bool {
+	return !token.Protected && stopWords.Contains(tenant, language, token.Text)
+}
+
+// key returns the key occurrences of token are counted under. Protected
+// tokens are not stemmed.
+func (a analyzer) key(language string, token utils.Token) string {
+	return termKey(language, token.Text, a.stemming && !token.Protected)
+}
+
+// field is one section of a document together with its name and weight.
+type field struct {
+	name   string
+	text   string
+	weight float64
+	decay  bool
+}
+
+// fields returns the non-empty sections of doc in reading order. Only the body
+// is subject to position decay.
+func (w fieldWeights) fields(doc entity.Document) []field {
+	fields := []field{}
+	for _, f := range []field{
+		{name: entity.FieldTitle, text: doc.Title, weight: w.title},
+		{name: entity.FieldSummary, text: doc.Summary, weight: w.summary},
+		{name: entity.FieldHeadings, text: doc.Headings, weight: w.headings},
+		{name: entity.FieldBody, text: doc.Body, weight: w.body, decay: true},
+	} {
+		if strings.TrimSpace(f.text) != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// at returns the weight of the token at position pos of f. With position
+// decay the weight starts at twice the field weight and approaches the field
+// weight, halving the boost every halfLife tokens.
+func (w fieldWeights) at(f field, pos int) float64 {
+	if !f.decay || w.halfLife == 0 {
+		return f.weight
+	}
+	return f.weight * (1 + math.Pow(0.5, float64(pos)/float64(w.halfLife)))
+}
+
+// documentText joins the fields of doc for language detection.
+func documentText(doc entity.Document) string {
+	return strings.Join([]string{doc.Title, doc.Summary, doc.Headings, doc.Body}, "\n")
+}
+
+// documentLanguage returns the language of doc, detecting it when unset.
+func documentLanguage(doc entity.Document) string {
+	if doc.Language != "" {
+		return doc.Language
+	}
+	return utils.DetectLanguage(documentText(doc))
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestNewFieldWeights(t *testing.T) {
+	weights := newFieldWeights(config.Extractor{TitleWeight: 3, BodyWeight: -1, PositionHalfLife: -5})
+
+	defaults := config.DefaultExtractor()
+	if weights.title != 3 {
+		t.Errorf("Expected title weight 3, got %v", weights.title)
+	}
+	if weights.body != defaults.BodyWeight || weights.summary != defaults.SummaryWeight {
+		t.Errorf("Expected default body and summary weights, got %v and %v", weights.body, weights.summary)
+	}
+	if weights.halfLife != 0 {
+		t.Errorf("Expected a negative half-life to disable decay, got %d", weights.halfLife)
+	}
+}
+
+func TestFieldWeights_Fields(t *testing.T) {
+	weights := newFieldWeights(config.DefaultExtractor())
+
+	fields := weights.fields(entity.Document{Title: "Title", Body: "Body", Summary: "  ", Headings: "Heading"})
+	if len(fields) != 3 {
+		t.Fatalf("Expected the blank summary to be skipped, got %v", fields)
+	}
+	if fields[0].text != "Title" || fields[0].decay {
+		t.Errorf("Expected the title first without decay, got %v", fields[0])
+	}
+	if fields[1].text != "Heading" || fields[1].weight != config.DefaultExtractor().HeadingWeight {
+		t.Errorf("Expected the headings with the heading weight, got %v", fields[1])
+	}
+	if fields[2].text != "Body" || !fields[2].decay {
+		t.Errorf("Expected the body last with decay, got %v", fields[2])
+	}
+}
+
+func TestFieldWeights_At(t *testing.T) {
+	body := field{text: "body", weight: 1, decay: true}
+	title := field{text: "title", weight: 2}
+
+	flat := newFieldWeights(config.DefaultExtractor())
+	if w := flat.at(body, 100); w != 1 {
+		t.Errorf("Expected no decay without a half-life, got %v", w)
+	}
+
+	decaying := newFieldWeights(config.Extractor{PositionHalfLife: 10})
+	tests := []struct {
+		name     string
+		field    field
+		pos      int
+		expected float64
+	}{
+		{name: "First body token", field: body, pos: 0, expected: 2},
+		{name: "Half-life", field: body, pos: 10, expected: 1.5},
+		{name: "Two half-lives", field: body, pos: 20, expected: 1.25},
+		{name: "Title does not decay", field: title, pos: 20, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := decaying.at(tt.field, tt.pos); math.Abs(w-tt.expected) > 1e-9 {
+				t.Errorf("Expected %v, got %v", tt.expected, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// EnsembleExtractor is the name the ensemble is registered under.
+const EnsembleExtractor = "ensemble"
+
+// Methods of combining the tags of the ensemble members.
+const (
+	// EnsembleRRF sums weight / (k + rank) over the members ranking a tag
+	EnsembleRRF = "rrf"
+	// EnsembleVote sums weight * score / top score over the members, so
+	// every member votes for its tags by how confident it is
+	EnsembleVote = "vote"
+)
+
+const defaultRRFK = 60
+
+// ensembleMember is a registered extractor taking part in an ensemble.
+type ensembleMember struct {
+	RegisteredExtractor
+	weight float64
+}
+
+// EnsembleExtractorService runs several extractors on the same document in
+// parallel and fuses their rankings, so a tag only one algorithm got wrong
+// does not make it to the top.
+type EnsembleExtractorService struct {
+	members []ensembleMember
+	method  string
+	k       float64
+}
+
+// NewEnsembleExtractorService combines the extractors of cfg.Weights, looked
+// up in extractors. Members run and rank in alphabetical order, which breaks
+// ties between tags first ranked by different members.
+func NewEnsembleExtractorService(extractors *ExtractorRegistry, cfg config.Ensemble) (*EnsembleExtractorService, error) {
+	if cfg.Method == "" {
+		cfg.Method = EnsembleRRF
+	}
+	if cfg.Method != EnsembleRRF && cfg.Method != EnsembleVote {
+		return nil, fmt.Errorf("unknown ensemble method: %s", cfg.Method)
+	}
+	if cfg.RRFK <= 0 {
+		cfg.RRFK = defaultRRFK
+	}
+	if len(cfg.Weights) == 0 {
+		return nil, fmt.Errorf("ensemble has no extractors")
+	}
+
+	names := make([]string, 0, len(cfg.Weights))
+	for name := range cfg.Weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	members := make([]ensembleMember, 0, len(names))
+	for _, name := range names {
+		if name == EnsembleExtractor {
+			return nil, fmt.Errorf("ensemble cannot contain itself")
+		}
+		registered, err := extractors.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Weights[name] <= 0 {
+			return nil, fmt.Errorf("ensemble weight of %s must be positive", name)
+		}
+		members = append(members, ensembleMember{RegisteredExtractor: registered, weight: cfg.Weights[name]})
+	}
+
+	return &EnsembleExtractorService{
+		members: members,
+		method:  cfg.Method,
+		k:       cfg.RRFK,
+	}, nil
+}
+
+func (e *EnsembleExtractorService) ExtractTags(doc entity.Document) []string {
+	return e.tagLimits().Tags(e.ExtractScoredTags(doc))
+}
+
+// tagLimits returns the limits of the first member; the members are
+// configured alike.
+func (e *EnsembleExtractorService) tagLimits() TagLimits {
+	return extractorLimits(e.members[0].Extractor)
+}
+
+// tagAnalyzer returns the analyzer of the first member, so the tags of the
+// ensemble are explained with the configured field weights.
+func (e *EnsembleExtractorService) tagAnalyzer() analyzer {
+	return extractorAnalyzer(e.members[0].Extractor)
+}
+
+// ExtractScoredTags runs every member on doc and ranks the tags by their
+// fused score. Tags are merged by their normalized form and emitted as the
+// first member ranking them wrote them.
+func (e *EnsembleExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	results := make([][]entity.ScoredTag, len(e.members))
+	var wg sync.WaitGroup
+	for i, member := range e.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = member.Extractor.ExtractScoredTags(doc)
+		}()
+	}
+	wg.Wait()
+
+	terms := []rankedTerm{}
+	index := make(map[string]int)
+	for i, scoredTags := range results {
+		top := 0.0
+		for _, st := range scoredTags {
+			top = max(top, st.Score)
+		}
+
+		seen := make(map[string]bool)
+		for rank, st := range scoredTags {
+			key := normalizeTag(st.Tag)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			var score float64
+			switch e.method {
+			case EnsembleVote:
+				if top > 0 {
+					score = e.members[i].weight * st.Score / top
+				}
+			default:
+				score = e.members[i].weight / (e.k + float64(rank+1))
+			}
+
+			n, ok := index[key]
+			if !ok {
+				n = len(terms)
+				index[key] = n
+				terms = append(terms, rankedTerm{term: st.Tag, first: rank})
+			}
+			terms[n].score += score
+			terms[n].first = min(terms[n].first, rank)
+			terms[n].frequency = max(terms[n].frequency, st.Frequency)
+			if terms[n].entityType == "" && st.Type != "" {
+				terms[n].entityType = st.Type
+				terms[n].entityID = st.ID
+			}
+		}
+	}
+	return rankTerms(terms)
+}
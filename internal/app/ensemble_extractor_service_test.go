@@ -0,0 +1,109 @@
+package app
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func ensembleRegistry() *ExtractorRegistry {
+	registry := NewExtractorRegistry(FrequencyExtractor)
+	registry.Register(FrequencyExtractor, "1", &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "go", Score: 10},
+		{Tag: "docker", Score: 5},
+		{Tag: "kubernetes", Score: 1},
+	}})
+	registry.Register(RakeExtractor, "1", &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "kubernetes", Score: 3},
+		{Tag: "Go", Score: 2},
+		{Tag: "rust", Score: 1},
+	}})
+	return registry
+}
+
+func TestEnsembleExtractorService_ExtractScoredTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Ensemble
+		expected []entity.ScoredTag
+	}{
+		{
+			name: "reciprocal rank fusion",
+			cfg:  config.Ensemble{Weights: map[string]float64{FrequencyExtractor: 1, RakeExtractor: 1}, Method: EnsembleRRF},
+			expected: []entity.ScoredTag{
+				{Tag: "go", Score: 1.0/61 + 1.0/62},
+				{Tag: "kubernetes", Score: 1.0/63 + 1.0/61},
+				{Tag: "docker", Score: 1.0 / 62},
+				{Tag: "rust", Score: 1.0 / 63},
+			},
+		},
+		{
+			name: "weighted voting",
+			cfg:  config.Ensemble{Weights: map[string]float64{FrequencyExtractor: 1, RakeExtractor: 2}, Method: EnsembleVote},
+			expected: []entity.ScoredTag{
+				{Tag: "go", Score: 1 + 2*2.0/3},
+				{Tag: "kubernetes", Score: 0.1 + 2},
+				{Tag: "rust", Score: 2.0 / 3},
+				{Tag: "docker", Score: 0.5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ensemble, err := NewEnsembleExtractorService(ensembleRegistry(), tt.cfg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			result := ensemble.ExtractScoredTags(entity.Document{})
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %d tags, got %v", len(tt.expected), result)
+			}
+			for i, st := range result {
+				if st.Tag != tt.expected[i].Tag || math.Abs(st.Score-tt.expected[i].Score) > 1e-9 {
+					t.Errorf("Expected tag %d to be %+v, got %+v", i, tt.expected[i], st)
+				}
+			}
+		})
+	}
+}
+
+func TestEnsembleExtractorService_KeepsEntities(t *testing.T) {
+	registry := NewExtractorRegistry(FrequencyExtractor)
+	registry.Register(FrequencyExtractor, "1", &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "nasa", Score: 2}}})
+	registry.Register(TextRankExtractor, "1", &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "NASA", Score: 1, Type: entity.EntityAcronym}}})
+
+	ensemble, err := NewEnsembleExtractorService(registry, config.Ensemble{Weights: map[string]float64{FrequencyExtractor: 1, TextRankExtractor: 1}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result := ensemble.ExtractScoredTags(entity.Document{})
+	if len(result) != 1 || result[0].Type != entity.EntityAcronym {
+		t.Errorf("Expected one acronym tag, got %+v", result)
+	}
+}
+
+func TestNewEnsembleExtractorService_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Ensemble
+	}{
+		{name: "no extractors", cfg: config.Ensemble{}},
+		{name: "unknown extractor", cfg: config.Ensemble{Weights: map[string]float64{TFIDFExtractor: 1}}},
+		{name: "unknown method", cfg: config.Ensemble{Weights: map[string]float64{RakeExtractor: 1}, Method: "borda"}},
+		{name: "itself", cfg: config.Ensemble{Weights: map[string]float64{EnsembleExtractor: 1}}},
+		{name: "zero weight", cfg: config.Ensemble{Weights: map[string]float64{RakeExtractor: 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewEnsembleExtractorService(ensembleRegistry(), tt.cfg); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
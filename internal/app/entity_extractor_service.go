@@ -0,0 +1,177 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// EntityExtractorService adds the named entities of a document, such as
+// "New York Times" or "NASA", to the tags of another extractor. Entities are
+// found by their capitalization, which the extractors lose when they lowercase
+// the text, and are emitted as written with an entity type.
+type EntityExtractorService struct {
+	extractor port.TagExtractor
+	boost     float64
+}
+
+func NewEntityExtractorService(extractor port.TagExtractor, cfg config.Entities) *EntityExtractorService {
+	if cfg.Boost <= 0 {
+		cfg.Boost = 1
+	}
+	return &EntityExtractorService{
+		extractor: extractor,
+		boost:     cfg.Boost,
+	}
+}
+
+func (e *EntityExtractorService) ExtractTags(doc entity.Document) []string {
+	return e.tagLimits().Tags(e.ExtractScoredTags(doc))
+}
+
+// tagLimits returns the limits of the wrapped extractor.
+func (e *EntityExtractorService) tagLimits() TagLimits {
+	return extractorLimits(e.extractor)
+}
+
+// tagAnalyzer returns the analyzer of the wrapped extractor.
+func (e *EntityExtractorService) tagAnalyzer() analyzer {
+	return extractorAnalyzer(e.extractor)
+}
+
+// ExtractScoredTags adds the entities of doc to the tags of the wrapped
+// extractor, see mergeEntities.
+func (e *EntityExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	return mergeEntities(e.extractor.ExtractScoredTags(doc), documentEntities(doc), e.boost)
+}
+
+// ExplainTags explains scoredTags with the wrapped extractor.
+func (e *EntityExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explain(e.extractor, doc, scoredTags)
+}
+
+// mergeEntities scores every entity by what the extractor made of it, times
+// the boost: the score of a tag naming it if there is one, else the mean
+// score of its words. Entities the extractor ignored entirely, such as "US"
+// whose lowercase form is a stop word, start from the lowest score. Tags
+// naming an entity are replaced by it; if such a tag already was an entity
+// its score is not boosted a second time.
+func mergeEntities(scoredTags []entity.ScoredTag, entities []*documentEntity, boost float64) []entity.ScoredTag {
+	if len(entities) == 0 {
+		return scoredTags
+	}
+
+	tagIndex := make(map[string]int)
+	wordScores := make(map[string]float64)
+	lowest := 0.0
+	for i, st := range scoredTags {
+		key := normalizeTag(st.Tag)
+		if _, ok := tagIndex[key]; !ok {
+			tagIndex[key] = i
+		}
+		for _, word := range strings.Fields(key) {
+			wordScores[word] = max(wordScores[word], st.Score)
+		}
+		if st.Score > 0 && (lowest == 0 || st.Score < lowest) {
+			lowest = st.Score
+		}
+	}
+	if lowest == 0 {
+		lowest = 1
+	}
+
+	ranked := make([]rankedTerm, 0, len(scoredTags)+len(entities))
+	replaced := make(map[int]bool)
+	for n, ent := range entities {
+		score := 0.0
+		first := len(scoredTags) + n
+		frequency := ent.mentions
+		for _, key := range ent.keys {
+			i, ok := tagIndex[key]
+			if !ok || replaced[i] {
+				continue
+			}
+			replaced[i] = true
+			first = min(first, i)
+			frequency = max(frequency, scoredTags[i].Frequency)
+			if st := scoredTags[i]; st.Type != "" {
+				score = max(score, st.Score)
+			} else {
+				score = max(score, st.Score*boost)
+			}
+		}
+		if score == 0 {
+			base := 0.0
+			for _, key := range ent.keys {
+				words := strings.Fields(key)
+				mean := 0.0
+				for _, word := range words {
+					mean += wordScores[word] / float64(len(words))
+				}
+				base = max(base, mean)
+			}
+			if base <= 0 {
+				base = lowest
+			}
+			score = base * boost
+		}
+
+		ranked = append(ranked, rankedTerm{
+			term:       ent.forms.best(),
+			score:      score,
+			first:      first,
+			entityType: ent.entityType,
+			entityID:   ent.id,
+			frequency:  frequency,
+		})
+	}
+	for i, st := range scoredTags {
+		if !replaced[i] {
+			ranked = append(ranked, rankedTerm{term: st.Tag, score: st.Score, first: i, entityType: st.Type, entityID: st.ID, frequency: st.Frequency})
+		}
+	}
+
+	return rankTerms(ranked)
+}
+
+// documentEntity is a distinct entity of a document with the normalized
+// names it was mentioned by, the spellings it is emitted as and the number of
+// mentions.
+type documentEntity struct {
+	keys       []string
+	entityType string
+	id         string
+	forms      surfaceForms
+	mentions   int
+}
+
+// documentEntities detects the entities of the summary and body of doc in
+// order of first mention. Titles and headings are skipped because they are
+// often written in title case.
+func documentEntities(doc entity.Document) []*documentEntity {
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	entities := []*documentEntity{}
+	index := make(map[string]*documentEntity)
+	for _, text := range []string{doc.Summary, doc.Body} {
+		for _, m := range utils.DetectEntities(text, stopWords, doc.Tenant, language) {
+			key := normalizeTag(m.Text)
+			ent, ok := index[key]
+			if !ok {
+				ent = &documentEntity{keys: []string{key}, entityType: entity.EntityProperNoun}
+				index[key] = ent
+				entities = append(entities, ent)
+			}
+			if m.Acronym {
+				ent.entityType = entity.EntityAcronym
+			}
+			ent.forms.add(m.Text)
+			ent.mentions++
+		}
+	}
+	return entities
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestEntityExtractorService_ExtractScoredTags(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "saeed", Score: 4},
+		{Tag: "times", Score: 3},
+		{Tag: "york", Score: 2},
+		{Tag: "reported", Score: 2},
+	}}
+	doc := entity.Document{Body: "A story in the New York Times reported that Saeed met US officials."}
+
+	extractor := NewEntityExtractorService(inner, config.Entities{Boost: 2})
+	expected := []entity.ScoredTag{
+		// the tag "saeed" is replaced by the entity
+		{Tag: "Saeed", Score: 8, Type: entity.EntityProperNoun},
+		// "us" is a stop word the extractor dropped; it starts from the lowest score
+		{Tag: "US", Score: 4, Type: entity.EntityAcronym},
+		// mean of new (0), york (2) and times (3)
+		{Tag: "New York Times", Score: 10.0 / 3, Type: entity.EntityProperNoun},
+		{Tag: "times", Score: 3},
+		{Tag: "york", Score: 2},
+		{Tag: "reported", Score: 2},
+	}
+
+	result := extractor.ExtractScoredTags(doc)
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+	for i, st := range result {
+		if st.Tag != expected[i].Tag || st.Type != expected[i].Type || math.Abs(st.Score-expected[i].Score) > 1e-9 {
+			t.Errorf("Expected %v at %d, got %v", expected[i], i, st)
+		}
+	}
+}
+
+func TestEntityExtractorService_NoEntities(t *testing.T) {
+	scoredTags := []entity.ScoredTag{{Tag: "compilers", Score: 2}, {Tag: "speed", Score: 1}}
+	extractor := NewEntityExtractorService(&scoredTagExtractor{scoredTags: scoredTags}, config.Entities{Boost: 1.5})
+
+	// title case in titles does not make entities
+	result := extractor.ExtractScoredTags(entity.Document{Title: "Faster Compilers", Body: "compilers got faster."})
+	if !reflect.DeepEqual(result, scoredTags) {
+		t.Errorf("Expected the inner tags unchanged, got %v", result)
+	}
+}
+
+func TestEntityExtractorService_WithExtractor(t *testing.T) {
+	extractor := NewEntityExtractorService(NewTagExtractorService(), config.Entities{Boost: 1.5})
+	tags := extractor.ExtractTags(entity.Document{
+		Title: "Space agencies",
+		Body:  "Engineers at NASA tested engines. NASA engineers said the engines passed, and Saeed agreed.",
+	})
+
+	if len(tags) == 0 || tags[0] != "NASA" {
+		t.Errorf("Expected NASA to rank first, got %v", tags)
+	}
+	for _, tag := range tags {
+		if tag == "nasa" || tag == "saeed" {
+			t.Errorf("Expected '%s' to be replaced by its entity, got %v", tag, tags)
+		}
+	}
+}
+
+func TestTaxonomyExtractorService_KeepsEntityType(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "Golang", Score: 2, Type: entity.EntityProperNoun},
+		{Tag: "go", Score: 1},
+	}}
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{{Tag: "go", Aliases: []string{"golang"}}}}
+
+	extractor := NewTaxonomyExtractorService(inner, vocabulary, config.Taxonomy{})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []entity.ScoredTag{{Tag: "go", Score: 3, Type: entity.EntityProperNoun}}
+	if result := extractor.ExtractScoredTags(entity.Document{}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestArticleService_ProcessArticles_Entities(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "launch", Score: 2}, {Tag: "probe", Score: 1}}}
+	service := NewArticleServiceWithExtractor(mockRepo, NewEntityExtractorService(inner, config.Entities{Boost: 1.5}))
+
+	articles := []*entity.Article{{Title: "Probe launch", Body: "the probe launch by NASA went well"}}
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []entity.TagEntity{{Tag: "NASA", Type: entity.EntityAcronym}}
+	if len(mockRepo.articles) != 1 || !reflect.DeepEqual(mockRepo.articles[0].Entities, expected) {
+		t.Errorf("Expected entities %v to be saved, got %+v", expected, mockRepo.articles)
+	}
+}
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// ErrEmptyCorpus is returned for an evaluation corpus without articles.
+var ErrEmptyCorpus = errors.New("evaluation corpus has no articles")
+
+// EvalReport holds the scores of every evaluated extractor on a corpus.
+type EvalReport struct {
+	K          int             `json:"k"`
+	Articles   int             `json:"articles"`
+	Extractors []ExtractorEval `json:"extractors"`
+}
+
+// ExtractorEval holds the scores of an extractor averaged over the articles
+// of the corpus, and the scores of each article.
+type ExtractorEval struct {
+	Extractor    string        `json:"extractor"`
+	Version      string        `json:"version"`
+	PrecisionAtK float64       `json:"precision_at_k"`
+	Precision    float64       `json:"precision"`
+	Recall       float64       `json:"recall"`
+	F1           float64       `json:"f1"`
+	MAP          float64       `json:"map"`
+	Articles     []ArticleEval `json:"articles"`
+}
+
+// ArticleEval holds the scores of an extractor on one article and how its
+// top tags differ from the expected ones.
+type ArticleEval struct {
+	ID               string   `json:"id"`
+	PrecisionAtK     float64  `json:"precision_at_k"`
+	Precision        float64  `json:"precision"`
+	Recall           float64  `json:"recall"`
+	F1               float64  `json:"f1"`
+	AveragePrecision float64  `json:"average_precision"`
+	Extracted        []string `json:"extracted"`
+	Missing          []string `json:"missing,omitempty"`
+	Unexpected       []string `json:"unexpected,omitempty"`
+}
+
+// LoadEvalCorpus reads a gold-standard corpus from the JSONL file at path:
+// one article per line with the fields of entity.Article, whose tags are the
+// expected tags. Blank lines are skipped. Articles without an id are
+// numbered by their line.
+func LoadEvalCorpus(path string) ([]*entity.Article, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	corpus, err := ReadEvalCorpus(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return corpus, nil
+}
+
+// ReadEvalCorpus reads a corpus in the format of LoadEvalCorpus from r.
+func ReadEvalCorpus(r io.Reader) ([]*entity.Article, error) {
+	corpus := []*entity.Article{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var article entity.Article
+		if err := json.Unmarshal(scanner.Bytes(), &article); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(article.Tags) == 0 {
+			return nil, fmt.Errorf("line %d: article has no expected tags", line)
+		}
+		if article.ID == "" {
+			article.ID = fmt.Sprintf("line-%d", line)
+		}
+		corpus = append(corpus, &article)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(corpus) == 0 {
+		return nil, ErrEmptyCorpus
+	}
+	return corpus, nil
+}
+
+// Evaluate runs every extractor of extractors on the articles of corpus and
+// scores its top k tags against their expected tags. Tags are kept within the
+// configured limits of each extractor, with k in place of its maximum. Tags
+// match when they normalize alike, so "Golang" matches "golang".
+func Evaluate(extractors *ExtractorRegistry, corpus []*entity.Article, k int) (*EvalReport, error) {
+	if len(corpus) == 0 {
+		return nil, ErrEmptyCorpus
+	}
+	if k <= 0 {
+		k = defaultMaxTags
+	}
+
+	report := &EvalReport{K: k, Articles: len(corpus)}
+	for _, name := range extractors.Names() {
+		registered, err := extractors.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		report.Extractors = append(report.Extractors, evaluateExtractor(registered, corpus, k))
+	}
+	return report, nil
+}
+
+// evaluateExtractor scores registered on every article of corpus and
+// averages the scores.
+func evaluateExtractor(registered RegisteredExtractor, corpus []*entity.Article, k int) ExtractorEval {
+	eval := ExtractorEval{
+		Extractor: registered.Name,
+		Version:   registered.Version,
+		Articles:  make([]ArticleEval, 0, len(corpus)),
+	}
+	limits := extractorLimits(registered.Extractor).Override(TagLimits{MaxTags: k})
+	for _, article := range corpus {
+		extracted := limits.Tags(registered.Extractor.ExtractScoredTags(articleDocument(article)))
+		a := scoreTags(article.ID, extracted, article.Tags, k)
+		eval.PrecisionAtK += a.PrecisionAtK
+		eval.Precision += a.Precision
+		eval.Recall += a.Recall
+		eval.F1 += a.F1
+		eval.MAP += a.AveragePrecision
+		eval.Articles = append(eval.Articles, a)
+	}
+
+	n := float64(len(corpus))
+	eval.PrecisionAtK /= n
+	eval.Precision /= n
+	eval.Recall /= n
+	eval.F1 /= n
+	eval.MAP /= n
+	return eval
+}
+
+// scoreTags scores the extracted tags of an article against the expected
+// ones. Precision at k is the number of expected tags found over k, so
+// extracting fewer than k tags counts against it, while precision is the
+// share of the extracted tags that are expected. Recall is the share of the
+// expected tags that were extracted, and average
+// precision averages the precision at the rank of every expected tag found,
+// over the number of expected tags that fit in k.
+func scoreTags(id string, extracted, expected []string, k int) ArticleEval {
+	want := make(map[string]string, len(expected))
+	for _, tag := range expected {
+		want[normalizeTag(tag)] = tag
+	}
+
+	a := ArticleEval{ID: id, Extracted: extracted}
+	found := make(map[string]bool)
+	hits := 0
+	sumPrecision := 0.0
+	for i, tag := range extracted {
+		key := normalizeTag(tag)
+		if _, ok := want[key]; !ok || found[key] {
+			a.Unexpected = append(a.Unexpected, tag)
+			continue
+		}
+		found[key] = true
+		hits++
+		sumPrecision += float64(hits) / float64(i+1)
+	}
+	for _, tag := range expected {
+		if key := normalizeTag(tag); !found[key] {
+			a.Missing = append(a.Missing, tag)
+			found[key] = true // report duplicates once
+		}
+	}
+
+	a.PrecisionAtK = float64(hits) / float64(k)
+	if len(extracted) > 0 {
+		a.Precision = float64(hits) / float64(len(extracted))
+	}
+	a.Recall = float64(hits) / float64(len(want))
+	if a.Precision+a.Recall > 0 {
+		a.F1 = 2 * a.Precision * a.Recall / (a.Precision + a.Recall)
+	}
+	a.AveragePrecision = sumPrecision / float64(min(len(want), k))
+	return a
+}
@@ -0,0 +1,220 @@
+package app
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestScoreTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		extracted []string
+		expected  []string
+		k         int
+		want      ArticleEval
+	}{
+		{
+			name:      "Partial match",
+			extracted: []string{"Go", "weather", "generics", "cats"},
+			expected:  []string{"go", "generics", "compiler"},
+			k:         4,
+			// AP = (1/1 + 2/3) / 3
+			want: ArticleEval{
+				PrecisionAtK:     0.5,
+				Precision:        0.5,
+				Recall:           2.0 / 3,
+				F1:               4.0 / 7,
+				AveragePrecision: 5.0 / 9,
+				Missing:          []string{"compiler"},
+				Unexpected:       []string{"weather", "cats"},
+			},
+		},
+		{
+			name:      "Perfect ranking cut at k",
+			extracted: []string{"go", "generics"},
+			expected:  []string{"go", "generics", "compiler"},
+			k:         2,
+			want: ArticleEval{
+				PrecisionAtK:     1,
+				Precision:        1,
+				Recall:           2.0 / 3,
+				F1:               0.8,
+				AveragePrecision: 1,
+				Missing:          []string{"compiler"},
+			},
+		},
+		{
+			name:      "Duplicates count once",
+			extracted: []string{"Golang", "golang"},
+			expected:  []string{"golang", "GOLANG"},
+			k:         2,
+			want: ArticleEval{
+				PrecisionAtK:     0.5,
+				Precision:        0.5,
+				Recall:           1,
+				F1:               2.0 / 3,
+				AveragePrecision: 1,
+				Unexpected:       []string{"golang"},
+			},
+		},
+		{
+			name:      "Fewer tags than k",
+			extracted: []string{"go"},
+			expected:  []string{"go", "generics"},
+			k:         4,
+			want: ArticleEval{
+				PrecisionAtK:     0.25,
+				Precision:        1,
+				Recall:           0.5,
+				F1:               2.0 / 3,
+				AveragePrecision: 0.5,
+				Missing:          []string{"generics"},
+			},
+		},
+		{
+			name:     "Nothing extracted",
+			expected: []string{"go"},
+			k:        10,
+			want:     ArticleEval{Missing: []string{"go"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreTags("a1", tt.extracted, tt.expected, tt.k)
+			for _, m := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"precision at k", got.PrecisionAtK, tt.want.PrecisionAtK},
+				{"precision", got.Precision, tt.want.Precision},
+				{"recall", got.Recall, tt.want.Recall},
+				{"f1", got.F1, tt.want.F1},
+				{"average precision", got.AveragePrecision, tt.want.AveragePrecision},
+			} {
+				if math.Abs(m.got-m.want) > 1e-9 {
+					t.Errorf("Expected %s %v, got %v", m.name, m.want, m.got)
+				}
+			}
+			if !reflect.DeepEqual(got.Missing, tt.want.Missing) || !reflect.DeepEqual(got.Unexpected, tt.want.Unexpected) {
+				t.Errorf("Expected missing %v and unexpected %v, got %v and %v", tt.want.Missing, tt.want.Unexpected, got.Missing, got.Unexpected)
+			}
+		})
+	}
+}
+
+func TestReadEvalCorpus(t *testing.T) {
+	corpus, err := ReadEvalCorpus(strings.NewReader(`{"id": "go", "title": "Go", "body": "Go is fast.", "tags": ["go"]}
+
+{"title": "Rust", "body": "Rust is safe.", "tags": ["rust", "safety"]}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(corpus) != 2 || corpus[0].ID != "go" || corpus[1].ID != "line-3" {
+		t.Fatalf("Expected articles go and line-3, got %v", corpus)
+	}
+	if !reflect.DeepEqual(corpus[1].Tags, []string{"rust", "safety"}) {
+		t.Errorf("Expected the expected tags, got %v", corpus[1].Tags)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "Invalid JSON", input: `{"title": "Go"`, err: "line 1"},
+		{name: "No expected tags", input: "\n" + `{"title": "Go", "body": "Go"}`, err: "line 2: article has no expected tags"},
+		{name: "Empty", input: "\n\n", err: ErrEmptyCorpus.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadEvalCorpus(strings.NewReader(tt.input)); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadEvalCorpus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gold.jsonl")
+	if err := os.WriteFile(path, []byte(`{"title": "Go", "body": "Go", "tags": ["go"]}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if corpus, err := LoadEvalCorpus(path); err != nil || len(corpus) != 1 {
+		t.Errorf("Expected 1 article, got %v (%v)", corpus, err)
+	}
+
+	if _, err := LoadEvalCorpus(filepath.Join(t.TempDir(), "missing.jsonl")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	extractors := NewExtractorRegistry(FrequencyExtractor)
+	extractors.Register(FrequencyExtractor, "1", &MockTagExtractor{tags: []string{"go", "generics", "weather"}})
+	extractors.Register(RakeExtractor, "2", &MockTagExtractor{tags: []string{"weather", "cats"}})
+	corpus := []*entity.Article{
+		{ID: "a1", Title: "Go", Body: "Go generics", Tags: []string{"go", "generics"}},
+		{ID: "a2", Title: "Go", Body: "Go", Tags: []string{"go"}},
+	}
+
+	report, err := Evaluate(extractors, corpus, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.K != 2 || report.Articles != 2 || len(report.Extractors) != 2 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+
+	frequency, rake := report.Extractors[0], report.Extractors[1]
+	if frequency.Extractor != FrequencyExtractor || rake.Extractor != RakeExtractor || rake.Version != "2" {
+		t.Fatalf("Expected extractors in name order, got %s and %s", frequency.Extractor, rake.Extractor)
+	}
+	// a1 is tagged perfectly; a2 gets go and the unexpected generics
+	if frequency.PrecisionAtK != 0.75 || frequency.Precision != 0.75 || frequency.Recall != 1 || frequency.MAP != 1 {
+		t.Errorf("Expected P@K 0.75, P 0.75, R 1 and MAP 1, got %+v", frequency)
+	}
+	if rake.F1 != 0 || rake.MAP != 0 {
+		t.Errorf("Expected no matches for rake, got %+v", rake)
+	}
+	if len(frequency.Articles) != 2 || !reflect.DeepEqual(frequency.Articles[1].Unexpected, []string{"generics"}) {
+		t.Errorf("Expected the diff of every article, got %+v", frequency.Articles)
+	}
+
+	if _, err := Evaluate(extractors, nil, 2); !errors.Is(err, ErrEmptyCorpus) {
+		t.Errorf("Expected ErrEmptyCorpus, got %v", err)
+	}
+}
+
+func TestEvaluate_TagLimits(t *testing.T) {
+	cfg := config.DefaultExtractor()
+	cfg.MinFrequency = 2
+	extractors := NewExtractorRegistry(FrequencyExtractor)
+	extractors.Register(FrequencyExtractor, "1", NewTagExtractorServiceWithConfig(cfg))
+	corpus := []*entity.Article{
+		{ID: "a1", Body: "Generics in Go. Go generics compile fast. Weather.", Tags: []string{"go", "generics"}},
+	}
+
+	report, err := Evaluate(extractors, corpus, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// tags occurring once are dropped as they are in production
+	a := report.Extractors[0].Articles[0]
+	if len(a.Unexpected) != 0 || len(a.Missing) != 0 {
+		t.Fatalf("Expected only the tags occurring twice, got %v", a.Extracted)
+	}
+	if math.Abs(a.PrecisionAtK-2.0/3) > 1e-9 || a.Precision != 1 {
+		t.Errorf("Expected P@K 2/3 and P 1, got %v and %v", a.PrecisionAtK, a.Precision)
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// explain explains scoredTags with extractor if it is a TagExplainer, and
+// otherwise with the analyzer of extractor.
+func explain(extractor port.TagExtractor, doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	if explainer, ok := extractor.(port.TagExplainer); ok {
+		return explainer.ExplainTags(doc, scoredTags)
+	}
+	return explainTags(doc, extractorAnalyzer(extractor), scoredTags, nil)
+}
+
+// analyzedExtractor is implemented by extractors that analyze documents with
+// configured settings, so their tags are explained with the same settings.
+type analyzedExtractor interface {
+	tagAnalyzer() analyzer
+}
+
+// extractorAnalyzer returns the analyzer of extractor, or one with the
+// default settings if it has none.
+func extractorAnalyzer(extractor port.TagExtractor) analyzer {
+	if analyzed, ok := extractor.(analyzedExtractor); ok {
+		return analyzed.tagAnalyzer()
+	}
+	return newAnalyzer(config.DefaultExtractor())
+}
+
+// explainTags finds every occurrence of the words of each tag in the fields
+// of doc and breaks down its score the way the term-frequency extractors
+// compute it. Stop words inside a tag must occur as well, so "bank of
+// america" is found as written. idf returns the inverse document frequency of
+// a tag from the forms it occurs in; nil means no idf.
+func explainTags(doc entity.Document, a analyzer, scoredTags []entity.ScoredTag, idf func(forms []string) float64) []entity.TagExplanation {
+	language := documentLanguage(doc)
+
+	patterns := make([][]string, len(scoredTags))
+	for i, st := range scoredTags {
+		for _, token := range a.tokens(language, st.Tag) {
+			patterns[i] = append(patterns[i], a.key(language, token))
+		}
+	}
+	matcher := utils.NewMatcher(patterns)
+
+	explanations := make([]entity.TagExplanation, len(scoredTags))
+	weighted := make([]float64, len(scoredTags))
+	forms := make([][]string, len(scoredTags))
+	for i, st := range scoredTags {
+		explanations[i] = entity.TagExplanation{
+			Tag:           st.Tag,
+			Score:         st.Score,
+			IDF:           1,
+			PositionBoost: 1,
+			Occurrences:   []entity.TagOccurrence{},
+		}
+	}
+
+	for _, f := range a.weights.fields(doc) {
+		tokens := a.tokens(language, f.text)
+		keys := make([]string, len(tokens))
+		for j, token := range tokens {
+			keys[j] = a.key(language, token)
+		}
+
+		for _, m := range matcher.FindAll(keys) {
+			e := &explanations[m.Pattern]
+			e.Frequency++
+			e.FieldWeight += f.weight
+			e.Occurrences = append(e.Occurrences, entity.TagOccurrence{
+				Field: f.name,
+				Start: tokens[m.Start].Start,
+				End:   tokens[m.End-1].End,
+			})
+			weighted[m.Pattern] += a.weights.at(f, m.Start)
+			forms[m.Pattern] = append(forms[m.Pattern], phraseText(tokens[m.Start:m.End]))
+		}
+	}
+
+	for i := range explanations {
+		e := &explanations[i]
+		if e.FieldWeight > 0 {
+			e.PositionBoost = weighted[i] / e.FieldWeight
+		}
+		if idf != nil {
+			e.IDF = idf(forms[i])
+		}
+	}
+	return explanations
+}
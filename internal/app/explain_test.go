@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+var explainDoc = entity.Document{
+	Title: "Go Generics",
+	Body:  "Generics arrived in Go 1.18. Go generics use type parameters.",
+}
+
+func TestTagExtractorService_ExplainTags(t *testing.T) {
+	cfg := config.DefaultExtractor()
+	cfg.PositionHalfLife = 1
+	extractor := NewTagExtractorServiceWithConfig(cfg)
+
+	explanations := extractor.ExplainTags(explainDoc, []entity.ScoredTag{
+		{Tag: "generics", Score: 5.0078125},
+		{Tag: "type parameters", Score: 1},
+	})
+	expected := []entity.TagExplanation{
+		{
+			Tag:         "generics",
+			Score:       5.0078125,
+			Frequency:   3,
+			IDF:         1,
+			FieldWeight: 4,
+			// the body occurrences at tokens 0 and 7 weigh 2 and 1 + 0.5^7
+			PositionBoost: 5.0078125 / 4,
+			Occurrences: []entity.TagOccurrence{
+				{Field: entity.FieldTitle, Start: 3, End: 11},
+				{Field: entity.FieldBody, Start: 0, End: 8},
+				{Field: entity.FieldBody, Start: 32, End: 40},
+			},
+		},
+		{
+			Tag:           "type parameters",
+			Score:         1,
+			Frequency:     1,
+			IDF:           1,
+			FieldWeight:   1,
+			PositionBoost: (1 + math.Pow(0.5, 9)) / 1,
+			Occurrences:   []entity.TagOccurrence{{Field: entity.FieldBody, Start: 45, End: 60}},
+		},
+	}
+
+	if !reflect.DeepEqual(explanations, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, explanations)
+	}
+	if score := extractor.ExtractScoredTags(explainDoc)[0]; score.Tag != "generics" || score.Score != expected[0].Score {
+		t.Errorf("Expected the breakdown to match the score, got %+v", score)
+	}
+}
+
+func TestTFIDFExtractorService_ExplainTags(t *testing.T) {
+	mockRepo := &MockDocumentFrequencyRepository{
+		df: &entity.DocumentFrequencies{TotalDocuments: 99, Terms: map[string]int{"generics": 9}},
+	}
+	extractor := NewTFIDFExtractorService(mockRepo, config.DefaultExtractor())
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	explanations := extractor.ExplainTags(explainDoc, []entity.ScoredTag{{Tag: "generics"}, {Tag: "absent"}})
+	if e := explanations[0]; e.IDF != idf(99, 9) || e.Frequency != 3 {
+		t.Errorf("Expected the idf of 9 in 99 documents, got %+v", e)
+	}
+	if e := explanations[1]; e.Frequency != 0 || len(e.Occurrences) != 0 || e.IDF != idf(99, 0) {
+		t.Errorf("Expected an unmentioned tag to have no occurrences, got %+v", e)
+	}
+}
+
+func TestExplain_Wrapped(t *testing.T) {
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{{Tag: "Go", Aliases: []string{"golang"}}}}
+	taxonomy := NewTaxonomyExtractorService(NewTagExtractorService(), vocabulary, config.Taxonomy{Enabled: true})
+	if err := taxonomy.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the taxonomy explains with the wrapped extractor; a plain extractor
+	// with the default weights
+	for _, extractor := range []port.TagExtractor{taxonomy, &scoredTagExtractor{}} {
+		explanations := explain(extractor, explainDoc, []entity.ScoredTag{{Tag: "Go", Score: 5}})
+		if len(explanations) != 1 || explanations[0].Frequency != 3 || explanations[0].FieldWeight != 4 {
+			t.Errorf("Expected Go to occur 3 times, got %+v", explanations)
+		}
+	}
+}
+
+func TestExplain_Ensemble(t *testing.T) {
+	cfg := config.DefaultExtractor()
+	cfg.TitleWeight = 5
+	extractors := NewExtractorRegistry(FrequencyExtractor)
+	extractors.Register(FrequencyExtractor, "1", NewTagExtractorServiceWithConfig(cfg))
+	extractors.Register(RakeExtractor, "1", NewRakeExtractorService(cfg))
+	ensemble, err := NewEnsembleExtractorService(extractors, config.Ensemble{
+		Weights: map[string]float64{FrequencyExtractor: 1, RakeExtractor: 1},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the ensemble cannot explain its tags, but its members' field weights apply
+	explanations := explain(ensemble, explainDoc, []entity.ScoredTag{{Tag: "Go", Score: 5}})
+	if len(explanations) != 1 || explanations[0].Frequency != 3 || explanations[0].FieldWeight != 7 {
+		t.Errorf("Expected Go to occur 3 times with a title weight of 5, got %+v", explanations)
+	}
+}
+
+func TestArticleService_ExplainTags(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	service := NewArticleServiceWithExtractor(mockRepo, NewTagExtractorService())
+	article := &entity.Article{Title: explainDoc.Title, Body: explainDoc.Body}
+
+	explanations, err := service.ExplainTags(context.Background(), article, ProcessOptions{MaxTags: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(explanations) != 2 || explanations[0].Tag != article.Tags[0] || explanations[1].Tag != article.Tags[1] {
+		t.Errorf("Expected an explanation per tag in order, got %+v for %v", explanations, article.Tags)
+	}
+	if len(mockRepo.articles) != 0 {
+		t.Errorf("Expected nothing to be saved, got %d articles", len(mockRepo.articles))
+	}
+
+	// explanations are only stored when asked for
+	for _, explain := range []bool{false, true} {
+		mockRepo.articles = nil
+		articles := []*entity.Article{{Title: explainDoc.Title, Body: explainDoc.Body}}
+		if _, err := service.ProcessArticlesWithOptions(context.Background(), articles, ProcessOptions{Explain: explain}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stored := len(mockRepo.articles[0].Explanations) > 0; stored != explain {
+			t.Errorf("Expected explanations stored %v, got %+v", explain, mockRepo.articles[0].Explanations)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// Names of the built-in extractors.
+const (
+	FrequencyExtractor = "frequency"
+	TFIDFExtractor     = "tfidf"
+	RakeExtractor      = "rake"
+	TextRankExtractor  = "textrank"
+)
+
+// ExtractorVersions holds the version of each built-in extractor. A version is
+// bumped whenever a change alters the tags an extractor produces for the same
+// input, so stored articles tell which revision tagged them.
+var ExtractorVersions = map[string]string{
+	FrequencyExtractor: "1",
+	TFIDFExtractor:     "1",
+	RakeExtractor:      "1",
+	TextRankExtractor:  "1",
+	EnsembleExtractor:  "1",
+}
+
+// Names of the decorators wrapping the registered extractors.
+const (
+	EntityDecorator    = "entities"
+	GazetteerDecorator = "gazetteer"
+	TaxonomyDecorator  = "taxonomy"
+	FeedbackDecorator  = "feedback"
+)
+
+// DecoratorVersions holds the version of each decorator, bumped like those of
+// the extractors. Wrap adds the name and version of a decorator to the
+// versions of the extractors it wraps.
+var DecoratorVersions = map[string]string{
+	EntityDecorator:    "1",
+	GazetteerDecorator: "1",
+	TaxonomyDecorator:  "1",
+	FeedbackDecorator:  "1",
+}
+
+// ErrUnknownExtractor is returned for an extractor name that is not
+// registered.
+var ErrUnknownExtractor = errors.New("unknown tag extractor")
+
+// RegisteredExtractor is a tag extractor together with the name and version
+// it is registered under.
+type RegisteredExtractor struct {
+	Name      string
+	Version   string
+	Extractor port.TagExtractor
+}
+
+// ExtractorRegistry holds the tag extractors that can be selected by name.
+type ExtractorRegistry struct {
+	extractors  map[string]RegisteredExtractor
+	defaultName string
+}
+
+// NewExtractorRegistry returns an empty registry whose default extractor is
+// defaultName.
+func NewExtractorRegistry(defaultName string) *ExtractorRegistry {
+	return &ExtractorRegistry{
+		extractors:  make(map[string]RegisteredExtractor),
+		defaultName: defaultName,
+	}
+}
+
+// Register adds extractor under name, replacing any extractor of that name.
+func (r *ExtractorRegistry) Register(name, version string, extractor port.TagExtractor) {
+	r.extractors[name] = RegisteredExtractor{Name: name, Version: version, Extractor: extractor}
+}
+
+// Get returns the extractor registered under name, or the default extractor
+// for an empty name.
+func (r *ExtractorRegistry) Get(name string) (RegisteredExtractor, error) {
+	if name == "" {
+		name = r.defaultName
+	}
+	registered, ok := r.extractors[name]
+	if !ok {
+		return RegisteredExtractor{}, fmt.Errorf("%w: %s", ErrUnknownExtractor, name)
+	}
+	return registered, nil
+}
+
+// Default returns the default extractor.
+func (r *ExtractorRegistry) Default() (RegisteredExtractor, error) {
+	return r.Get("")
+}
+
+// Names returns the registered names in alphabetical order.
+func (r *ExtractorRegistry) Names() []string {
+	names := make([]string, 0, len(r.extractors))
+	for name := range r.extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Wrap replaces every registered extractor with wrap applied to it, keeping
+// its name. It is used to add decorators such as entity linking to all
+// extractors alike. As decorators change the tags, the decorator and its
+// version are appended to the version of every extractor, so frequency "1"
+// wrapped by entities "1" becomes "1+entities.1".
+func (r *ExtractorRegistry) Wrap(decorator, version string, wrap func(port.TagExtractor) port.TagExtractor) {
+	for name, registered := range r.extractors {
+		registered.Extractor = wrap(registered.Extractor)
+		registered.Version = fmt.Sprintf("%s+%s.%s", registered.Version, decorator, version)
+		r.extractors[name] = registered
+	}
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+func TestExtractorRegistry_Get(t *testing.T) {
+	frequency := &MockTagExtractor{tags: []string{"frequent"}}
+	rake := &MockTagExtractor{tags: []string{"key phrase"}}
+
+	registry := NewExtractorRegistry(FrequencyExtractor)
+	registry.Register(FrequencyExtractor, "1", frequency)
+	registry.Register(RakeExtractor, "2", rake)
+
+	tests := []struct {
+		name     string
+		request  string
+		expected RegisteredExtractor
+	}{
+		{name: "default", request: "", expected: RegisteredExtractor{Name: FrequencyExtractor, Version: "1", Extractor: frequency}},
+		{name: "by name", request: RakeExtractor, expected: RegisteredExtractor{Name: RakeExtractor, Version: "2", Extractor: rake}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registered, err := registry.Get(tt.request)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if registered != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, registered)
+			}
+		})
+	}
+
+	if _, err := registry.Get("word2vec"); !errors.Is(err, ErrUnknownExtractor) {
+		t.Errorf("Expected ErrUnknownExtractor, got %v", err)
+	}
+	if names := registry.Names(); !reflect.DeepEqual(names, []string{FrequencyExtractor, RakeExtractor}) {
+		t.Errorf("Expected sorted names, got %v", names)
+	}
+}
+
+func TestExtractorRegistry_DefaultMissing(t *testing.T) {
+	registry := NewExtractorRegistry(TFIDFExtractor)
+	registry.Register(RakeExtractor, "1", &MockTagExtractor{})
+
+	if _, err := registry.Default(); !errors.Is(err, ErrUnknownExtractor) {
+		t.Errorf("Expected ErrUnknownExtractor, got %v", err)
+	}
+}
+
+func TestExtractorRegistry_Wrap(t *testing.T) {
+	registry := NewExtractorRegistry(FrequencyExtractor)
+	registry.Register(FrequencyExtractor, "1", &MockTagExtractor{tags: []string{"golang"}})
+
+	registry.Register(RakeExtractor, "2", &MockTagExtractor{tags: []string{"golang"}})
+
+	registry.Wrap(EntityDecorator, "1", func(extractor port.TagExtractor) port.TagExtractor {
+		return &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "wrapped", Score: 1}}}
+	})
+	registry.Wrap(TaxonomyDecorator, "3", func(extractor port.TagExtractor) port.TagExtractor {
+		return extractor
+	})
+
+	registered, _ := registry.Get(FrequencyExtractor)
+	if !reflect.DeepEqual(registered.Extractor.ExtractTags(entity.Document{}), []string{"wrapped"}) {
+		t.Errorf("Expected the wrapped extractor, got %+v", registered)
+	}
+	// the decorators are recorded in order in the version of every extractor
+	if registered.Version != "1+entities.1+taxonomy.3" {
+		t.Errorf("Expected version 1+entities.1+taxonomy.3, got %s", registered.Version)
+	}
+	if rake, _ := registry.Get(RakeExtractor); rake.Version != "2+entities.1+taxonomy.3" {
+		t.Errorf("Expected version 2+entities.1+taxonomy.3, got %s", rake.Version)
+	}
+}
+
+func TestArticleService_ProcessArticlesWithOptions(t *testing.T) {
+	registry := NewExtractorRegistry(FrequencyExtractor)
+	registry.Register(FrequencyExtractor, "1", &MockTagExtractor{tags: []string{"a", "b", "c", "d"}})
+	registry.Register(TextRankExtractor, "3", &MockTagExtractor{tags: []string{"ranked"}})
+
+	t.Run("defaults", func(t *testing.T) {
+		mockRepo := &MockArticleRepository{}
+		service := NewArticleServiceWithRegistry(mockRepo, registry)
+		if _, err := service.ProcessArticles(t.Context(), []*entity.Article{{Title: "t", Body: "b"}}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		saved := mockRepo.articles[0]
+		if saved.Extractor != FrequencyExtractor || saved.ExtractorVersion != "1" || len(saved.Tags) != 4 {
+			t.Errorf("Expected all tags of frequency 1, got %+v", saved)
+		}
+	})
+
+	t.Run("max tags and min score", func(t *testing.T) {
+		mockRepo := &MockArticleRepository{}
+		service := NewArticleServiceWithRegistry(mockRepo, registry)
+		// scores are 4, 3, 2, 1, so only "a" reaches 0.8 of the top score
+		opts := ProcessOptions{MaxTags: 2, MinScore: 0.8}
+		if _, err := service.ProcessArticlesWithOptions(t.Context(), []*entity.Article{{Title: "t", Body: "b"}}, opts); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tags := mockRepo.articles[0].Tags; !reflect.DeepEqual(tags, []string{"a"}) {
+			t.Errorf("Expected [a], got %v", tags)
+		}
+	})
+
+	t.Run("selected extractor", func(t *testing.T) {
+		mockRepo := &MockArticleRepository{}
+		service := NewArticleServiceWithRegistry(mockRepo, registry)
+		opts := ProcessOptions{Extractor: TextRankExtractor}
+		if _, err := service.ProcessArticlesWithOptions(t.Context(), []*entity.Article{{Title: "t", Body: "b"}}, opts); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		saved := mockRepo.articles[0]
+		if saved.Extractor != TextRankExtractor || saved.ExtractorVersion != "3" || !reflect.DeepEqual(saved.Tags, []string{"ranked"}) {
+			t.Errorf("Expected the textrank tags, got %+v", saved)
+		}
+	})
+
+	t.Run("unknown extractor", func(t *testing.T) {
+		mockRepo := &MockArticleRepository{}
+		service := NewArticleServiceWithRegistry(mockRepo, registry)
+		count, err := service.ProcessArticlesWithOptions(t.Context(), []*entity.Article{{Title: "t", Body: "b"}}, ProcessOptions{Extractor: "lda"})
+		if !errors.Is(err, ErrUnknownExtractor) || count != 0 || len(mockRepo.articles) != 0 {
+			t.Errorf("Expected ErrUnknownExtractor and nothing saved, got %d, %v", count, err)
+		}
+	})
+
+	t.Run("no registry", func(t *testing.T) {
+		service := NewArticleServiceWithExtractor(&MockArticleRepository{}, &MockTagExtractor{})
+		if _, err := service.ProcessArticlesWithOptions(t.Context(), nil, ProcessOptions{Extractor: RakeExtractor}); !errors.Is(err, ErrUnknownExtractor) {
+			t.Errorf("Expected ErrUnknownExtractor, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// feedbackWeights maps a source and a normalized tag to the factor its score
+// is multiplied by.
+type feedbackWeights map[string]map[string]float64
+
+// FeedbackExtractorService boosts the tags editors accepted or added and
+// penalizes those they rejected, per source, in the tags of another
+// extractor. The learned weights are read from an in-memory snapshot that is
+// swapped atomically on refresh.
+type FeedbackExtractorService struct {
+	extractor port.TagExtractor
+	source    port.TagFeedbackRepository
+	weights   *atomic.Pointer[feedbackWeights]
+	strength  float64
+	smoothing float64
+}
+
+func NewFeedbackExtractorService(extractor port.TagExtractor, source port.TagFeedbackRepository, cfg config.Feedback) *FeedbackExtractorService {
+	if cfg.Strength <= 0 {
+		cfg.Strength = 1
+	}
+	if cfg.Smoothing <= 0 {
+		cfg.Smoothing = 5
+	}
+	f := &FeedbackExtractorService{
+		extractor: extractor,
+		source:    source,
+		weights:   &atomic.Pointer[feedbackWeights]{},
+		strength:  cfg.Strength,
+		smoothing: cfg.Smoothing,
+	}
+	f.weights.Store(&feedbackWeights{})
+	return f
+}
+
+// Wrap returns a service that weights the tags of extractor with the same
+// snapshot, so refreshing f refreshes both.
+func (f *FeedbackExtractorService) Wrap(extractor port.TagExtractor) *FeedbackExtractorService {
+	return &FeedbackExtractorService{
+		extractor: extractor,
+		source:    f.source,
+		weights:   f.weights,
+		strength:  f.strength,
+		smoothing: f.smoothing,
+	}
+}
+
+// Refresh learns new weights from the decisions in the repository.
+func (f *FeedbackExtractorService) Refresh(ctx context.Context) error {
+	stats, err := f.source.GetTagFeedbackStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	// spellings that normalize alike, such as "Golang" and "golang", pool
+	// their decisions into one weight
+	type sourceTag struct{ source, key string }
+	counts := make(map[sourceTag]entity.TagFeedbackStats)
+	for _, s := range stats {
+		key := normalizeTag(s.Tag)
+		if key == "" {
+			continue
+		}
+		c := counts[sourceTag{s.Source, key}]
+		c.Accepted += s.Accepted
+		c.Rejected += s.Rejected
+		c.Added += s.Added
+		counts[sourceTag{s.Source, key}] = c
+	}
+
+	weights := feedbackWeights{}
+	for st, c := range counts {
+		if weights[st.source] == nil {
+			weights[st.source] = make(map[string]float64)
+		}
+		weights[st.source][st.key] = f.weight(c)
+	}
+	f.weights.Store(&weights)
+	return nil
+}
+
+// weight is e^(strength * balance), where balance runs from -1 for tags that
+// are always rejected to 1 for tags that are always accepted or added and is
+// damped by the smoothing for tags with few decisions.
+func (f *FeedbackExtractorService) weight(s entity.TagFeedbackStats) float64 {
+	positive := float64(s.Accepted + s.Added)
+	negative := float64(s.Rejected)
+	balance := (positive - negative) / (positive + negative + f.smoothing)
+	return math.Exp(f.strength * balance)
+}
+
+// StartRefresh refreshes the snapshot immediately and then on every interval
+// until ctx is cancelled.
+func (f *FeedbackExtractorService) StartRefresh(ctx context.Context, interval time.Duration) {
+	if err := f.Refresh(ctx); err != nil {
+		log.Printf("failed to refresh tag feedback: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.Refresh(ctx); err != nil {
+				log.Printf("failed to refresh tag feedback: %v", err)
+			}
+		}
+	}
+}
+
+func (f *FeedbackExtractorService) ExtractTags(doc entity.Document) []string {
+	return f.tagLimits().Tags(f.ExtractScoredTags(doc))
+}
+
+// tagLimits returns the limits of the wrapped extractor.
+func (f *FeedbackExtractorService) tagLimits() TagLimits {
+	return extractorLimits(f.extractor)
+}
+
+// tagAnalyzer returns the analyzer of the wrapped extractor.
+func (f *FeedbackExtractorService) tagAnalyzer() analyzer {
+	return extractorAnalyzer(f.extractor)
+}
+
+// ExtractScoredTags multiplies the scores of the wrapped extractor by the
+// weights learned for the source of doc and ranks the tags again.
+func (f *FeedbackExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	scoredTags := f.extractor.ExtractScoredTags(doc)
+	weights := (*f.weights.Load())[doc.Source]
+	if len(weights) == 0 {
+		return scoredTags
+	}
+
+	ranked := make([]rankedTerm, 0, len(scoredTags))
+	for pos, st := range scoredTags {
+		score := st.Score
+		if w, ok := weights[normalizeTag(st.Tag)]; ok {
+			score *= w
+		}
+		ranked = append(ranked, rankedTerm{
+			term:       st.Tag,
+			score:      score,
+			first:      pos,
+			entityType: st.Type,
+			entityID:   st.ID,
+			frequency:  st.Frequency,
+		})
+	}
+	return rankTerms(ranked)
+}
+
+// ExplainTags explains scoredTags with the wrapped extractor.
+func (f *FeedbackExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explain(f.extractor, doc, scoredTags)
+}
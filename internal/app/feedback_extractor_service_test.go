@@ -0,0 +1,231 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// MockTagFeedbackRepository is a mock implementation of TagFeedbackRepository
+type MockTagFeedbackRepository struct {
+	articles map[string]*entity.Article
+	feedback []entity.TagFeedback
+	stats    []entity.TagFeedbackStats
+	err      error
+}
+
+func (m *MockTagFeedbackRepository) GetArticle(ctx context.Context, id string) (*entity.Article, error) {
+	article, ok := m.articles[id]
+	if !ok {
+		return nil, port.ErrArticleNotFound
+	}
+	copied := *article
+	return &copied, nil
+}
+
+func (m *MockTagFeedbackRepository) UpdateArticleTags(ctx context.Context, id string, tags []string) error {
+	m.articles[id].Tags = tags
+	return nil
+}
+
+func (m *MockTagFeedbackRepository) SaveTagFeedback(ctx context.Context, feedback []entity.TagFeedback) error {
+	m.feedback = append(m.feedback, feedback...)
+	return nil
+}
+
+func (m *MockTagFeedbackRepository) GetTagFeedbackStats(ctx context.Context) ([]entity.TagFeedbackStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.stats, nil
+}
+
+func TestFeedbackExtractorService_ExtractTags(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "rust", Score: 3},
+		{Tag: "go", Score: 2},
+		{Tag: "js", Score: 1.5},
+	}}
+	repo := &MockTagFeedbackRepository{stats: []entity.TagFeedbackStats{
+		{Source: "blog", Tag: "Go", Accepted: 3, Added: 2},
+		{Source: "blog", Tag: "rust", Rejected: 5},
+	}}
+
+	extractor := NewFeedbackExtractorService(inner, repo, config.Feedback{})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{name: "Source with feedback", source: "blog", expected: []string{"go", "rust", "js"}},
+		{name: "Source without feedback", source: "news", expected: []string{"rust", "go", "js"}},
+		{name: "No source", expected: []string{"rust", "go", "js"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tags := extractor.ExtractTags(entity.Document{Source: tt.source}); !reflect.DeepEqual(tags, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tags)
+			}
+		})
+	}
+}
+
+func TestFeedbackExtractorService_Weight(t *testing.T) {
+	extractor := NewFeedbackExtractorService(nil, nil, config.Feedback{Strength: 2, Smoothing: 2})
+
+	tests := []struct {
+		name     string
+		stats    entity.TagFeedbackStats
+		expected float64
+	}{
+		{name: "No decisions", expected: 1},
+		{name: "Accepted", stats: entity.TagFeedbackStats{Accepted: 2}, expected: math.Exp(1)},
+		{name: "Rejected", stats: entity.TagFeedbackStats{Rejected: 6}, expected: math.Exp(-1.5)},
+		{name: "Balanced", stats: entity.TagFeedbackStats{Added: 3, Rejected: 3}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := extractor.weight(tt.stats); math.Abs(w-tt.expected) > 1e-9 {
+				t.Errorf("Expected %v, got %v", tt.expected, w)
+			}
+		})
+	}
+}
+
+func TestFeedbackExtractorService_WrapSharesWeights(t *testing.T) {
+	repo := &MockTagFeedbackRepository{}
+	feedback := NewFeedbackExtractorService(nil, repo, config.Feedback{})
+	extractor := feedback.Wrap(&scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "rust", Score: 2},
+		{Tag: "go", Score: 1},
+	}})
+
+	repo.stats = []entity.TagFeedbackStats{{Source: "blog", Tag: "rust", Rejected: 20}}
+	if err := feedback.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"go", "rust"}
+	if tags := extractor.ExtractTags(entity.Document{Source: "blog"}); !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+}
+
+func TestFeedbackExtractorService_RefreshMergesSpellings(t *testing.T) {
+	stats := []entity.TagFeedbackStats{
+		{Source: "blog", Tag: "Golang", Accepted: 4},
+		{Source: "blog", Tag: "golang", Rejected: 1},
+		{Source: "news", Tag: "golang", Rejected: 3},
+	}
+	expected := NewFeedbackExtractorService(nil, nil, config.Feedback{}).weight(entity.TagFeedbackStats{Accepted: 4, Rejected: 1})
+
+	// the weight must not depend on the order the aggregates arrive in
+	for _, order := range [][]int{{0, 1, 2}, {1, 0, 2}, {2, 1, 0}} {
+		repo := &MockTagFeedbackRepository{}
+		for _, i := range order {
+			repo.stats = append(repo.stats, stats[i])
+		}
+		extractor := NewFeedbackExtractorService(nil, repo, config.Feedback{})
+		if err := extractor.Refresh(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		weights := *extractor.weights.Load()
+		if w := weights["blog"]["golang"]; math.Abs(w-expected) > 1e-9 {
+			t.Errorf("Order %v: expected %v, got %v", order, expected, w)
+		}
+		if w := weights["news"]["golang"]; w >= 1 {
+			t.Errorf("Order %v: expected news to keep its own penalty, got %v", order, w)
+		}
+	}
+}
+
+func TestFeedbackExtractorService_RefreshErrorKeepsWeights(t *testing.T) {
+	repo := &MockTagFeedbackRepository{stats: []entity.TagFeedbackStats{{Source: "blog", Tag: "rust", Rejected: 20}}}
+	extractor := NewFeedbackExtractorService(&scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "rust", Score: 2},
+		{Tag: "go", Score: 1},
+	}}, repo, config.Feedback{})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	repo.err = errors.New("database unavailable")
+	if err := extractor.Refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	expected := []string{"go", "rust"}
+	if tags := extractor.ExtractTags(entity.Document{Source: "blog"}); !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+}
+
+func TestArticleService_SubmitTagFeedback(t *testing.T) {
+	repo := &MockTagFeedbackRepository{articles: map[string]*entity.Article{
+		"a1": {ID: "a1", Source: "blog", Tags: []string{"go", "rust", "article"}},
+	}}
+	service := NewArticleService(&MockArticleRepository{})
+	service.Feedback = repo
+
+	article, err := service.SubmitTagFeedback(context.Background(), "a1", []string{"go"}, []string{"Article"}, []string{"generics", "rust"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"go", "rust", "generics"}
+	if !reflect.DeepEqual(article.Tags, expected) {
+		t.Errorf("Expected tags %v, got %v", expected, article.Tags)
+	}
+	if !reflect.DeepEqual(repo.articles["a1"].Tags, expected) {
+		t.Errorf("Expected stored tags %v, got %v", expected, repo.articles["a1"].Tags)
+	}
+
+	if len(repo.feedback) != 4 {
+		t.Fatalf("Expected 4 decisions, got %d", len(repo.feedback))
+	}
+	for _, f := range repo.feedback {
+		if f.ArticleID != "a1" || f.Source != "blog" || f.CreatedAt.IsZero() {
+			t.Errorf("Unexpected decision %+v", f)
+		}
+	}
+	if repo.feedback[1].Tag != "Article" || repo.feedback[1].Action != entity.FeedbackReject {
+		t.Errorf("Expected the rejection of Article, got %+v", repo.feedback[1])
+	}
+}
+
+func TestArticleService_SubmitTagFeedback_Errors(t *testing.T) {
+	service := NewArticleService(&MockArticleRepository{})
+	if _, err := service.SubmitTagFeedback(context.Background(), "a1", []string{"go"}, nil, nil); !errors.Is(err, ErrFeedbackDisabled) {
+		t.Errorf("Expected ErrFeedbackDisabled, got %v", err)
+	}
+
+	service.Feedback = &MockTagFeedbackRepository{}
+	if _, err := service.SubmitTagFeedback(context.Background(), "missing", []string{"go"}, nil, nil); !errors.Is(err, port.ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+
+	// tags conflict when they normalize alike, not just when they lowercase alike
+	for _, decisions := range [][2][]string{
+		{{"Golang"}, {"golang"}},
+		{{"Node.JS"}, {" node.js "}},
+		{{"Go!"}, {"go"}},
+	} {
+		_, err := service.SubmitTagFeedback(context.Background(), "missing", decisions[0], decisions[1], nil)
+		if !errors.Is(err, ErrConflictingFeedback) {
+			t.Errorf("Keeping %q and rejecting %q: expected ErrConflictingFeedback, got %v", decisions[0], decisions[1], err)
+		}
+	}
+}
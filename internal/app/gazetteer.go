@@ -0,0 +1,175 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+const gazetteerExt = ".tsv"
+
+// Gazetteer links mentions of known entities in text to their entries. All
+// names and aliases are matched in one pass with an Aho-Corasick automaton.
+type Gazetteer struct {
+	entries []entity.GazetteerEntry
+	// names holds the normalized name of each pattern and owners the entry
+	// it belongs to; acronyms marks the names written in capitals, which
+	// only match text written in capitals, so "US" does not match "us"
+	names    []string
+	owners   []int
+	acronyms []bool
+	matcher  *utils.Matcher
+}
+
+// LoadGazetteer reads the gazetteer files at paths. Each path is either a
+// "<type>.tsv" file or a directory of them, such as "country.tsv" or
+// "company.tsv". Every line holds an ID, a canonical name and any number of
+// aliases, separated by tabs; blank lines and lines starting with '#' are
+// skipped.
+func LoadGazetteer(paths []string) (*Gazetteer, error) {
+	var files []string
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, root)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(root, "*"+gazetteerExt))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	var entries []entity.GazetteerEntry
+	for _, file := range files {
+		fileEntries, err := readGazetteerFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	return NewGazetteer(entries), nil
+}
+
+func readGazetteerFile(name string) ([]entity.GazetteerEntry, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	entityType := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	entries := []entity.GazetteerEntry{}
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if len(fields) < 2 || fields[0] == "" || fields[1] == "" {
+			return nil, fmt.Errorf("%s:%d: expected an ID and a name separated by a tab", name, n+1)
+		}
+		entries = append(entries, entity.GazetteerEntry{
+			ID:      fields[0],
+			Name:    fields[1],
+			Type:    entityType,
+			Aliases: fields[2:],
+		})
+	}
+	return entries, nil
+}
+
+// NewGazetteer indexes entries. A name shared by several entries links to the
+// first of them. Names written in capitals, such as "US", match case
+// sensitively; all others ignore case.
+func NewGazetteer(entries []entity.GazetteerEntry) *Gazetteer {
+	g := &Gazetteer{entries: entries}
+
+	var patterns [][]string
+	seen := make(map[string]bool)
+	for i, e := range entries {
+		for _, name := range append([]string{e.Name}, e.Aliases...) {
+			words := gazetteerWords(name)
+			key := strings.Join(words, " ")
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			patterns = append(patterns, words)
+			g.names = append(g.names, key)
+			g.owners = append(g.owners, i)
+			g.acronyms = append(g.acronyms, isCapitalized(name))
+		}
+	}
+	g.matcher = utils.NewMatcher(patterns)
+	return g
+}
+
+// gazetteerWords normalizes a name for matching, protecting the built-in
+// technical terms and folding letter variants the way tags are normalized.
+func gazetteerWords(text string) []string {
+	return tagTokenizer.Tokenize(utils.NormalizePersian(text))
+}
+
+// isCapitalized reports whether text has letters and all of them are
+// capitals.
+func isCapitalized(text string) bool {
+	letters := false
+	for _, r := range text {
+		if unicode.IsLower(r) {
+			return false
+		}
+		letters = letters || unicode.IsLetter(r)
+	}
+	return letters
+}
+
+// Size returns the number of entries.
+func (g *Gazetteer) Size() int {
+	return len(g.entries)
+}
+
+// gazetteerMention is an entry mentioned in a text under the normalized name
+// key.
+type gazetteerMention struct {
+	entry *entity.GazetteerEntry
+	key   string
+}
+
+// mentions returns the entries mentioned in text in order of appearance.
+// Where names overlap the longest wins, so "New York City" is not also read
+// as "New York". Names written in capitals are checked against the text as
+// written.
+func (g *Gazetteer) mentions(text string) []gazetteerMention {
+	tokens := tagTokenizer.Tokens(text)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = utils.NormalizePersian(token.Text)
+	}
+
+	mentions := []gazetteerMention{}
+	for _, m := range g.matcher.FindLongest(words) {
+		if g.acronyms[m.Pattern] && !isCapitalized(text[tokens[m.Start].Start:tokens[m.End-1].End]) {
+			continue
+		}
+		mentions = append(mentions, gazetteerMention{
+			entry: &g.entries[g.owners[m.Pattern]],
+			key:   g.names[m.Pattern],
+		})
+	}
+	return mentions
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// GazetteerExtractorService links the entities of a document to the entries
+// of a gazetteer and adds them to the tags of another extractor. Linked tags
+// carry the canonical name, ID and type of their entry, so "USA" and "United
+// States" both become the tag "United States" with ID "Q30".
+type GazetteerExtractorService struct {
+	extractor port.TagExtractor
+	gazetteer *Gazetteer
+	boost     float64
+}
+
+func NewGazetteerExtractorService(extractor port.TagExtractor, gazetteer *Gazetteer, cfg config.Gazetteer) *GazetteerExtractorService {
+	if cfg.Boost <= 0 {
+		cfg.Boost = 1
+	}
+	return &GazetteerExtractorService{
+		extractor: extractor,
+		gazetteer: gazetteer,
+		boost:     cfg.Boost,
+	}
+}
+
+func (g *GazetteerExtractorService) ExtractTags(doc entity.Document) []string {
+	return g.tagLimits().Tags(g.ExtractScoredTags(doc))
+}
+
+// tagLimits returns the limits of the wrapped extractor.
+func (g *GazetteerExtractorService) tagLimits() TagLimits {
+	return extractorLimits(g.extractor)
+}
+
+// tagAnalyzer returns the analyzer of the wrapped extractor.
+func (g *GazetteerExtractorService) tagAnalyzer() analyzer {
+	return extractorAnalyzer(g.extractor)
+}
+
+// ExtractScoredTags adds the linked entities of doc to the tags of the
+// wrapped extractor and scores them the way detected entities are scored.
+func (g *GazetteerExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	return mergeEntities(g.extractor.ExtractScoredTags(doc), g.linkedEntities(doc), g.boost)
+}
+
+// ExplainTags explains scoredTags with the wrapped extractor. Tags emitted
+// under a canonical name only show the occurrences of that name.
+func (g *GazetteerExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explain(g.extractor, doc, scoredTags)
+}
+
+// linkedEntities returns the gazetteer entries mentioned in any field of doc
+// in order of first mention. Fields are matched separately so a name never
+// spans two of them.
+func (g *GazetteerExtractorService) linkedEntities(doc entity.Document) []*documentEntity {
+	entities := []*documentEntity{}
+	index := make(map[*entity.GazetteerEntry]*documentEntity)
+	for _, text := range []string{doc.Title, doc.Summary, doc.Headings, doc.Body} {
+		for _, m := range g.gazetteer.mentions(text) {
+			ent, ok := index[m.entry]
+			if !ok {
+				ent = &documentEntity{
+					keys:       []string{normalizeTag(m.entry.Name)},
+					entityType: m.entry.Type,
+					id:         m.entry.ID,
+				}
+				ent.forms.add(m.entry.Name)
+				index[m.entry] = ent
+				entities = append(entities, ent)
+			}
+			ent.mentions++
+			if !slices.Contains(ent.keys, m.key) {
+				ent.keys = append(ent.keys, m.key)
+			}
+		}
+	}
+	return entities
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+var testGazetteer = NewGazetteer([]entity.GazetteerEntry{
+	{ID: "Q30", Name: "United States", Type: "country", Aliases: []string{"USA", "US"}},
+	{ID: "Q95", Name: "Google", Type: "company"},
+	{ID: "Q60", Name: "New York City", Type: "city", Aliases: []string{"NYC"}},
+})
+
+func TestGazetteerExtractorService_ExtractScoredTags(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "google", Score: 4},
+		{Tag: "usa", Score: 3},
+		{Tag: "offices", Score: 2},
+		{Tag: "NYC", Score: 3, Type: entity.EntityAcronym},
+	}}
+	doc := entity.Document{
+		Title: "Google expands in the USA",
+		Body:  "Google is opening offices across the United States, starting with NYC.",
+	}
+
+	extractor := NewGazetteerExtractorService(inner, testGazetteer, config.Gazetteer{Boost: 2})
+	expected := []entity.ScoredTag{
+		{Tag: "Google", Score: 8, Type: "company", ID: "Q95", Frequency: 2},
+		// "usa" and "united states" name the same entry
+		{Tag: "United States", Score: 6, Type: "country", ID: "Q30", Frequency: 2},
+		// an entity tag is linked without boosting it again
+		{Tag: "New York City", Score: 3, Type: "city", ID: "Q60", Frequency: 1},
+		{Tag: "offices", Score: 2},
+	}
+
+	if result := extractor.ExtractScoredTags(doc); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestGazetteerExtractorService_Unmentioned(t *testing.T) {
+	scoredTags := []entity.ScoredTag{{Tag: "weather", Score: 1}}
+	extractor := NewGazetteerExtractorService(&scoredTagExtractor{scoredTags: scoredTags}, testGazetteer, config.Gazetteer{})
+
+	// "googled" is not "google"
+	if result := extractor.ExtractScoredTags(entity.Document{Body: "She googled the weather."}); !reflect.DeepEqual(result, scoredTags) {
+		t.Errorf("Expected the inner tags unchanged, got %v", result)
+	}
+}
+
+func TestGazetteerExtractorService_CapitalizedAliases(t *testing.T) {
+	extractor := NewGazetteerExtractorService(&scoredTagExtractor{}, testGazetteer, config.Gazetteer{})
+
+	// "US" is only the country when written in capitals; "nyc" is a mistyped
+	// acronym rather than the city
+	doc := entity.Document{
+		Title: "Let us talk",
+		Body:  "Tell us about goroutines. Join us in nyc or Us.",
+	}
+	if result := extractor.ExtractScoredTags(doc); len(result) != 0 {
+		t.Errorf("Expected no linked entities, got %v", result)
+	}
+
+	doc = entity.Document{Body: "Tell us about the US market."}
+	result := extractor.ExtractScoredTags(doc)
+	if len(result) != 1 || result[0].ID != "Q30" || result[0].Frequency != 1 {
+		t.Errorf("Expected one mention of the United States, got %v", result)
+	}
+}
+
+func TestArticleService_ProcessArticles_LinkedEntities(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	extractor := NewGazetteerExtractorService(NewTagExtractorService(), testGazetteer, config.Gazetteer{Boost: 1.5})
+	service := NewArticleServiceWithExtractor(mockRepo, extractor)
+
+	articles := []*entity.Article{{Title: "Search", Body: "Google improves search results in the USA."}}
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []entity.TagEntity{
+		{Tag: "Google", Type: "company", ID: "Q95"},
+		{Tag: "United States", Type: "country", ID: "Q30"},
+	}
+	if len(mockRepo.articles) != 1 || !reflect.DeepEqual(mockRepo.articles[0].Entities, expected) {
+		t.Errorf("Expected linked entities %v, got %+v", expected, mockRepo.articles)
+	}
+}
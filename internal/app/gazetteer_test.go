@@ -0,0 +1,77 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func writeGazetteerFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadGazetteer(t *testing.T) {
+	dir := t.TempDir()
+	writeGazetteerFile(t, filepath.Join(dir, "gazetteers", "country.tsv"), "# id\tname\taliases\nQ30\tUnited States\tUSA\tUS of A\n\nQ794\tIran\n")
+	writeGazetteerFile(t, filepath.Join(dir, "gazetteers", "notes.txt"), "not a gazetteer")
+	writeGazetteerFile(t, filepath.Join(dir, "company.tsv"), "Q95\tGoogle\tAlphabet\n")
+
+	gazetteer, err := LoadGazetteer([]string{filepath.Join(dir, "gazetteers"), filepath.Join(dir, "company.tsv")})
+	if err != nil {
+		t.Fatalf("LoadGazetteer failed: %v", err)
+	}
+
+	expected := []entity.GazetteerEntry{
+		{ID: "Q30", Name: "United States", Type: "country", Aliases: []string{"USA", "US of A"}},
+		{ID: "Q794", Name: "Iran", Type: "country", Aliases: []string{}},
+		{ID: "Q95", Name: "Google", Type: "company", Aliases: []string{"Alphabet"}},
+	}
+	if !reflect.DeepEqual(gazetteer.entries, expected) {
+		t.Errorf("Expected %v, got %v", expected, gazetteer.entries)
+	}
+}
+
+func TestLoadGazetteer_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	malformed := filepath.Join(dir, "city.tsv")
+	writeGazetteerFile(t, malformed, "Q60\tNew York City\nQ90 Paris\n")
+	if _, err := LoadGazetteer([]string{malformed}); err == nil || err.Error() != malformed+":2: expected an ID and a name separated by a tab" {
+		t.Errorf("Expected an error for line 2, got %v", err)
+	}
+
+	if _, err := LoadGazetteer([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("Expected an error for a missing path")
+	}
+}
+
+func TestGazetteer_Mentions(t *testing.T) {
+	gazetteer := NewGazetteer([]entity.GazetteerEntry{
+		{ID: "Q60", Name: "New York City", Type: "city", Aliases: []string{"NYC", "New York"}},
+		{ID: "Q1384", Name: "New York", Type: "state"},
+		{ID: "Q95", Name: "Google", Type: "company"},
+	})
+
+	var result []string
+	for _, m := range gazetteer.mentions("Google opened an office in New York City, the biggest in NYC.") {
+		result = append(result, m.entry.ID+":"+m.key)
+	}
+
+	// "new york" links to the first entry that claims it
+	expected := []string{"Q95:google", "Q60:new york city", "Q60:nyc"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if m := gazetteer.mentions("new york"); len(m) != 1 || m[0].entry.ID != "Q60" {
+		t.Errorf("Expected 'new york' to link to Q60, got %v", m)
+	}
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// RakeExtractorService implements Rapid Automatic Keyword Extraction. Candidate
+// phrases are split at stop words and punctuation, every word is scored by its
+// degree over its frequency, and a phrase scores the sum of its words times
+// the highest field and position weight it occurs with.
+type RakeExtractorService struct {
+	maxPhraseWords int
+	analyzer       analyzer
+	limits         TagLimits
+}
+
+func NewRakeExtractorService(cfg config.Extractor) *RakeExtractorService {
+	if cfg.MaxPhraseWords <= 0 {
+		cfg.MaxPhraseWords = config.DefaultExtractor().MaxPhraseWords
+	}
+	return &RakeExtractorService{
+		maxPhraseWords: cfg.MaxPhraseWords,
+		analyzer:       newAnalyzer(cfg),
+		limits:         NewTagLimits(cfg),
+	}
+}
+
+func (r *RakeExtractorService) ExtractTags(doc entity.Document) []string {
+	return r.limits.Tags(r.ExtractScoredTags(doc))
+}
+
+func (r *RakeExtractorService) tagLimits() TagLimits {
+	return r.limits
+}
+
+func (r *RakeExtractorService) tagAnalyzer() analyzer {
+	return r.analyzer
+}
+
+// ExtractScoredTags returns every candidate phrase ranked by its RAKE score.
+func (r *RakeExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	phrases := []rakePhrase{}
+	for _, f := range r.analyzer.weights.fields(doc) {
+		phrases = append(phrases, r.candidatePhrases(stopWords, doc.Tenant, language, f)...)
+	}
+
+	// word frequency and degree over all candidate phrases
+	freq := make(map[string]int)
+	degree := make(map[string]int)
+	keys := make([][]string, len(phrases))
+	for i, phrase := range phrases {
+		keys[i] = make([]string, len(phrase.words))
+		for j, word := range phrase.words {
+			key := r.analyzer.key(language, word)
+			keys[i][j] = key
+			freq[key]++
+			degree[key] += len(phrase.words)
+		}
+	}
+
+	type candidate struct {
+		words  []string
+		weight float64
+		first  int
+		forms  surfaceForms
+	}
+	candidates := []*candidate{}
+	index := make(map[string]*candidate)
+	for pos, phrase := range phrases {
+		key := strings.Join(keys[pos], " ")
+		c, ok := index[key]
+		if !ok {
+			c = &candidate{words: keys[pos], first: pos}
+			index[key] = c
+			candidates = append(candidates, c)
+		}
+		c.weight = max(c.weight, phrase.weight)
+		c.forms.add(phraseText(phrase.words))
+	}
+
+	ranked := make([]rankedTerm, 0, len(candidates))
+	for _, c := range candidates {
+		score := 0.0
+		for _, word := range c.words {
+			score += float64(degree[word]) / float64(freq[word])
+		}
+		ranked = append(ranked, rankedTerm{term: c.forms.best(), score: score * c.weight, first: c.first, frequency: c.forms.total()})
+	}
+
+	return rankTerms(ranked)
+}
+
+// ExplainTags finds the occurrences of scoredTags in doc. Their scores are
+// RAKE scores, which the frequency breakdown only approximates.
+func (r *RakeExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explainTags(doc, r.analyzer, scoredTags, nil)
+}
+
+// rakePhrase is a candidate phrase with the weight of the field and position
+// it starts at.
+type rakePhrase struct {
+	words  []utils.Token
+	weight float64
+}
+
+// candidatePhrases splits the text of f into runs of content words delimited
+// by stop words and punctuation. Runs longer than maxPhraseWords are dropped;
+// a protected token is never a stop word.
+func (r *RakeExtractorService) candidatePhrases(stopWords *utils.StopWords, tenant, language string, f field) []rakePhrase {
+	phrases := []rakePhrase{}
+	pos := 0
+	for _, fragment := range r.analyzer.fragments(language, f.text) {
+		start := 0
+		for i := 0; i <= len(fragment); i++ {
+			if i < len(fragment) && !r.analyzer.isStopWord(stopWords, tenant, language, fragment[i]) {
+				continue
+			}
+			if phrase := fragment[start:i]; len(phrase) > 0 && len(phrase) <= r.maxPhraseWords {
+				phrases = append(phrases, rakePhrase{words: phrase, weight: r.analyzer.weights.at(f, pos+start)})
+			}
+			start = i + 1
+		}
+		pos += len(fragment)
+	}
+	return phrases
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestRakeExtractorService_ExtractScoredTags(t *testing.T) {
+	// flat field weights, so only degree and frequency decide
+	extractor := NewRakeExtractorService(config.Extractor{TitleWeight: 1})
+
+	title := "Machine learning in production"
+	body := "Machine learning models are everywhere. In practice, machine learning models and feature stores " +
+		"are paired with monitoring of data drift."
+
+	scoredTags := extractor.ExtractScoredTags(entity.Document{Title: title, Body: body})
+	if len(scoredTags) == 0 {
+		t.Fatal("Expected scored tags, got none")
+	}
+
+	// multi-word phrases collect the degree of all their words
+	if scoredTags[0].Tag != "machine learning models" {
+		t.Errorf("Expected 'machine learning models' to rank first, got %v", scoredTags)
+	}
+
+	found := make(map[string]bool)
+	for i, st := range scoredTags {
+		if found[st.Tag] {
+			t.Errorf("Duplicate phrase found: %s", st.Tag)
+		}
+		found[st.Tag] = true
+		if i > 0 && st.Score > scoredTags[i-1].Score {
+			t.Errorf("Scores are not sorted: %v", scoredTags)
+		}
+	}
+	for _, phrase := range []string{"machine learning", "feature stores", "data drift"} {
+		if !found[phrase] {
+			t.Errorf("Expected phrase '%s' in %v", phrase, scoredTags)
+		}
+	}
+}
+
+func TestRakeExtractorService_MaxPhraseWords(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxPhraseWords int
+		expected       []string
+	}{
+		{
+			name:           "Long phrase dropped",
+			maxPhraseWords: 2,
+			expected:       []string{"graph neural", "survey"},
+		},
+		{
+			name:           "Long phrase kept",
+			maxPhraseWords: 4,
+			expected:       []string{"large scale graph neural", "graph neural", "survey"},
+		},
+		{
+			name:           "Default cap",
+			maxPhraseWords: 0,
+			expected:       []string{"graph neural", "survey"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor := NewRakeExtractorService(config.Extractor{MaxPhraseWords: tt.maxPhraseWords})
+			tags := extractor.ExtractTags(entity.Document{Title: "Large scale graph neural", Body: "This is a survey of the graph neural"})
+
+			if strings.Join(tags, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %v, got %v", tt.expected, tags)
+			}
+		})
+	}
+}
+
+func TestRakeExtractorService_StopWordsAndPunctuation(t *testing.T) {
+	extractor := NewRakeExtractorService(config.DefaultExtractor())
+
+	tags := extractor.ExtractTags(entity.Document{Body: "The cat, the dog and the bird"})
+	expected := []string{"cat", "dog", "bird"}
+
+	if strings.Join(tags, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+}
+
+func TestRakeExtractorService_Stemming(t *testing.T) {
+	title := "Neural networks"
+	body := "This neural network is small. The neural networks are deep. A neural network is fast."
+
+	contains := func(tags []string, want string) bool {
+		for _, tag := range tags {
+			if tag == want {
+				return true
+			}
+		}
+		return false
+	}
+
+	tags := NewRakeExtractorService(config.DefaultExtractor()).ExtractTags(entity.Document{Title: title, Body: body})
+	if !contains(tags, "neural networks") || !contains(tags, "neural network") {
+		t.Errorf("Expected separate phrases without stemming, got %v", tags)
+	}
+
+	// both forms occur twice; the tie goes to the form seen first
+	tags = NewRakeExtractorService(config.Extractor{Stemming: true}).ExtractTags(entity.Document{Title: title, Body: body})
+	if !contains(tags, "neural networks") || contains(tags, "neural network") {
+		t.Errorf("Expected 'neural network' to be merged into 'neural networks', got %v", tags)
+	}
+}
+
+func TestRakeExtractorService_ProtectedTokens(t *testing.T) {
+	body := "Teams port C++ to Go 1.24. The node.js gateway stays."
+
+	extractor := NewRakeExtractorService(config.Extractor{MaxPhraseWords: 3, ProtectedTokens: true})
+	tags := extractor.ExtractTags(entity.Document{Body: body})
+
+	found := make(map[string]bool)
+	for _, tag := range tags {
+		found[tag] = true
+	}
+	// punctuation inside a protected token does not split the phrase
+	for _, want := range []string{"teams port c++", "go 1.24", "node.js gateway stays"} {
+		if !found[want] {
+			t.Errorf("Expected phrase '%s', got %v", want, tags)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// termKey returns the key occurrences of word are counted under: its stem
+// when stemming is enabled, the word itself otherwise.
+func termKey(language, word string, stemming bool) string {
+	if stemming {
+		return utils.Stem(language, word)
+	}
+	return word
+}
+
+// phraseText joins the normalized text of tokens into a phrase.
+func phraseText(tokens []utils.Token) string {
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+	return strings.Join(words, " ")
+}
+
+// surfaceForms counts the spellings a term occurred in, so a stemmed term can
+// be emitted as its most frequent surface form instead of its stem.
+type surfaceForms struct {
+	forms  []string
+	counts map[string]int
+}
+
+func (s *surfaceForms) add(form string) {
+	if s.counts == nil {
+		s.counts = make(map[string]int)
+	}
+	if s.counts[form] == 0 {
+		s.forms = append(s.forms, form)
+	}
+	s.counts[form]++
+}
+
+// total returns the number of occurrences of all forms.
+func (s *surfaceForms) total() int {
+	total := 0
+	for _, count := range s.counts {
+		total += count
+	}
+	return total
+}
+
+// best returns the most frequent form, preferring the one seen first on ties.
+func (s *surfaceForms) best() string {
+	best := ""
+	for _, form := range s.forms {
+		if best == "" || s.counts[form] > s.counts[best] {
+			best = form
+		}
+	}
+	return best
+}
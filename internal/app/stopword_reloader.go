@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// StopWordReloader loads stop-word lists from the configured paths and swaps
+// them in atomically, so extraction in flight is never blocked or sees a
+// partly loaded list.
+type StopWordReloader struct {
+	paths []string
+
+	mu          sync.Mutex
+	fingerprint string
+}
+
+func NewStopWordReloader(cfg config.StopWords) *StopWordReloader {
+	return &StopWordReloader{paths: cfg.Paths}
+}
+
+// Reload reads the lists and activates them. On error the active lists are
+// kept.
+func (r *StopWordReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reload(r.currentFingerprint())
+}
+
+// ReloadIfChanged reloads the lists when any stop-word file was added,
+// removed or modified since the last reload.
+func (r *StopWordReloader) ReloadIfChanged() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fingerprint := r.currentFingerprint()
+	if fingerprint == r.fingerprint {
+		return false, nil
+	}
+	return true, r.reload(fingerprint)
+}
+
+func (r *StopWordReloader) reload(fingerprint string) error {
+	stopWords, err := utils.LoadStopWords(r.paths)
+	if err != nil {
+		return err
+	}
+	utils.SetStopWords(stopWords)
+	r.fingerprint = fingerprint
+
+	languages, tenants := stopWords.Size()
+	log.Printf("loaded stop words for %d languages and %d tenants", languages, tenants)
+	return nil
+}
+
+// currentFingerprint identifies the set of stop-word files with their sizes
+// and modification times.
+func (r *StopWordReloader) currentFingerprint() string {
+	var b strings.Builder
+	for _, name := range utils.StopWordFiles(r.paths) {
+		info, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", name, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
+
+// Watch polls the stop-word files on every interval and reloads them when
+// they change, until ctx is cancelled.
+func (r *StopWordReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.ReloadIfChanged(); err != nil {
+				log.Printf("failed to reload stop words: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+func TestStopWordReloader(t *testing.T) {
+	t.Cleanup(func() { utils.SetStopWords(utils.DefaultStopWords()) })
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "en.txt")
+	if err := os.WriteFile(name, []byte("kubernetes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloader := NewStopWordReloader(config.StopWords{Paths: []string{dir}})
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	extractor := NewTagExtractorService()
+	title := "Kubernetes clusters"
+	body := "Kubernetes schedules the containers of a service across all of the clusters in the region."
+	for _, tag := range extractor.ExtractTags(entity.Document{Title: title, Body: body}) {
+		if tag == "kubernetes" {
+			t.Errorf("Expected 'kubernetes' to be filtered as a stop word")
+		}
+	}
+
+	if changed, err := reloader.ReloadIfChanged(); err != nil || changed {
+		t.Errorf("Expected no reload for unchanged files, got %v, %v", changed, err)
+	}
+
+	// rewrite the file with a later modification time
+	if err := os.WriteFile(name, []byte("clusters\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(name, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if changed, err := reloader.ReloadIfChanged(); err != nil || !changed {
+		t.Fatalf("Expected a reload after the file changed, got %v, %v", changed, err)
+	}
+	if utils.IsStopWordIn("en", "kubernetes") || !utils.IsStopWordIn("en", "clusters") {
+		t.Error("Expected the reloaded list to replace the old one")
+	}
+}
+
+func TestStopWordReloader_KeepsListsOnError(t *testing.T) {
+	t.Cleanup(func() { utils.SetStopWords(utils.DefaultStopWords()) })
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en.txt"), []byte("kubernetes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStopWordReloader(config.StopWords{Paths: []string{dir}}).Reload(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	broken := NewStopWordReloader(config.StopWords{Paths: []string{filepath.Join(dir, "missing")}})
+	if err := broken.Reload(); err == nil {
+		t.Fatal("Expected an error for a missing path")
+	}
+	if !utils.IsStopWordIn("en", "kubernetes") {
+		t.Error("Expected the active lists to be kept after a failed reload")
+	}
+}
+
+func TestStopWordReloader_TenantOverrides(t *testing.T) {
+	t.Cleanup(func() { utils.SetStopWords(utils.DefaultStopWords()) })
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "acme"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "acme", "en.txt"), []byte("kubernetes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStopWordReloader(config.StopWords{Paths: []string{dir}}).Reload(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	doc := entity.Document{
+		Title: "Kubernetes clusters",
+		Body:  "Kubernetes schedules the containers of a service across all of the clusters in the region.",
+	}
+	extractor := NewTagExtractorService()
+
+	if tags := extractor.ExtractTags(doc); len(tags) == 0 || tags[0] != "kubernetes" {
+		t.Errorf("Expected 'kubernetes' without a tenant, got %v", tags)
+	}
+	doc.Tenant = "acme"
+	for _, tag := range extractor.ExtractTags(doc) {
+		if tag == "kubernetes" {
+			t.Errorf("Expected 'kubernetes' to be a stop word for the tenant")
+		}
+	}
+}
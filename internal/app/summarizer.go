@@ -0,0 +1,137 @@
+package app
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// defaultSummarySentences is the summary length when none is configured.
+const defaultSummarySentences = 3
+
+// Summarizer picks the key sentences of an article body with TextRank over
+// sentences: two sentences are linked by the words they share, normalized by
+// their lengths, and the most central sentences make the summary.
+type Summarizer struct {
+	analyzer  analyzer
+	sentences int
+}
+
+// NewSummarizer returns a summarizer analyzing words like the extractors
+// configured by extractor.
+func NewSummarizer(cfg config.Summarizer, extractor config.Extractor) *Summarizer {
+	if cfg.Sentences <= 0 {
+		cfg.Sentences = defaultSummarySentences
+	}
+	return &Summarizer{
+		analyzer:  newAnalyzer(extractor),
+		sentences: cfg.Sentences,
+	}
+}
+
+// Summarize returns the n highest ranked sentences of the body of doc in
+// reading order; n <= 0 selects the configured length. A nil summarizer
+// returns none.
+func (s *Summarizer) Summarize(doc entity.Document, n int) []entity.SummarySentence {
+	if s == nil {
+		return nil
+	}
+	if n <= 0 {
+		n = s.sentences
+	}
+
+	sentences := utils.SplitSentences(doc.Body)
+	if len(sentences) == 0 {
+		return nil
+	}
+	scores := s.rank(doc, sentences)
+
+	order := make([]int, len(sentences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+	order = order[:min(n, len(order))]
+	sort.Ints(order)
+
+	summary := make([]entity.SummarySentence, 0, len(order))
+	for _, i := range order {
+		summary = append(summary, entity.SummarySentence{
+			Text:  sentences[i].Text,
+			Score: scores[i],
+			Start: sentences[i].Start,
+			End:   sentences[i].End,
+		})
+	}
+	return summary
+}
+
+// rank returns the TextRank score of every sentence. Sentences without
+// content words share no edges and keep the base score.
+func (s *Summarizer) rank(doc entity.Document, sentences []utils.Sentence) []float64 {
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	words := make([]map[string]bool, len(sentences))
+	for i, sentence := range sentences {
+		words[i] = make(map[string]bool)
+		for _, token := range s.analyzer.tokens(language, sentence.Text) {
+			if !s.analyzer.isStopWord(stopWords, doc.Tenant, language, token) {
+				words[i][s.analyzer.key(language, token)] = true
+			}
+		}
+	}
+
+	// similarity of every pair of sentences, indexed by their position
+	similarity := make([][]float64, len(sentences))
+	for i := range similarity {
+		similarity[i] = make([]float64, len(sentences))
+	}
+	for i := range sentences {
+		for j := i + 1; j < len(sentences); j++ {
+			w := sentenceSimilarity(words[i], words[j])
+			similarity[i][j], similarity[j][i] = w, w
+		}
+	}
+
+	edges := make([][]graphEdge, len(sentences))
+	for i, row := range similarity {
+		for j, w := range row {
+			if w > 0 {
+				edges[i] = append(edges[i], graphEdge{to: j, weight: w})
+			}
+		}
+	}
+	return pageRank(edges, nil)
+}
+
+// sentenceSimilarity is the number of words a and b share divided by the sum
+// of the logarithms of their lengths, so long sentences are not favored just
+// for being long.
+func sentenceSimilarity(a, b map[string]bool) float64 {
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	if shared == 0 {
+		return 0
+	}
+	return float64(shared) / (math.Log(float64(len(a))+1) + math.Log(float64(len(b))+1))
+}
+
+// summaryText joins the sentences of a summary.
+func summaryText(summary []entity.SummarySentence) string {
+	texts := make([]string, 0, len(summary))
+	for _, s := range summary {
+		texts = append(texts, s.Text)
+	}
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+const summaryBody = "Go is a programming language designed at Google. " +
+	"The weather was pleasant yesterday. " +
+	"Go programs compile quickly and the language has built-in concurrency. " +
+	"Concurrency in Go uses goroutines and channels. " +
+	"My cat sleeps all day."
+
+func TestSummarizer_Summarize(t *testing.T) {
+	summarizer := NewSummarizer(config.Summarizer{Sentences: 2}, config.Extractor{})
+	doc := entity.Document{Body: summaryBody, Language: "en"}
+
+	tests := []struct {
+		name     string
+		n        int
+		expected []string
+	}{
+		{
+			name: "Configured length",
+			expected: []string{
+				"Go is a programming language designed at Google.",
+				"Go programs compile quickly and the language has built-in concurrency.",
+			},
+		},
+		{
+			name: "Reading order",
+			n:    3,
+			expected: []string{
+				"Go is a programming language designed at Google.",
+				"Go programs compile quickly and the language has built-in concurrency.",
+				"Concurrency in Go uses goroutines and channels.",
+			},
+		},
+		{
+			name: "Longer than the body",
+			n:    10,
+			expected: []string{
+				"Go is a programming language designed at Google.",
+				"The weather was pleasant yesterday.",
+				"Go programs compile quickly and the language has built-in concurrency.",
+				"Concurrency in Go uses goroutines and channels.",
+				"My cat sleeps all day.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarizer.Summarize(doc, tt.n)
+			texts := []string{}
+			for _, s := range summary {
+				texts = append(texts, s.Text)
+				if doc.Body[s.Start:s.End] != s.Text {
+					t.Errorf("Offsets %d-%d do not match %q", s.Start, s.End, s.Text)
+				}
+			}
+			if !reflect.DeepEqual(texts, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, texts)
+			}
+		})
+	}
+}
+
+func TestSummarizer_Repeatable(t *testing.T) {
+	summarizer := NewSummarizer(config.Summarizer{Sentences: 3}, config.Extractor{})
+	// scores are compared exactly, so any change in summation order shows
+	doc := entity.Document{Language: "en", Body: "Raft elects a leader. The leader replicates the log. " +
+		"Followers store the log. Followers vote for a leader. A majority commits the log. " +
+		"Paxos also reaches consensus. Consensus needs a majority."}
+
+	first := summarizer.Summarize(doc, 0)
+	for run := 0; run < 100; run++ {
+		if summary := summarizer.Summarize(doc, 0); !reflect.DeepEqual(summary, first) {
+			t.Fatalf("Run %d: expected %v, got %v", run, first, summary)
+		}
+	}
+}
+
+func TestSummarizer_Empty(t *testing.T) {
+	var nilSummarizer *Summarizer
+	if summary := nilSummarizer.Summarize(entity.Document{Body: summaryBody}, 0); summary != nil {
+		t.Errorf("Expected no summary from a nil summarizer, got %v", summary)
+	}
+
+	summarizer := NewSummarizer(config.Summarizer{}, config.Extractor{})
+	if summary := summarizer.Summarize(entity.Document{Title: "Only a title"}, 0); len(summary) != 0 {
+		t.Errorf("Expected no summary without a body, got %v", summary)
+	}
+}
+
+func TestArticleService_Summary(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	service := NewArticleService(mockRepo)
+	service.Summarizer = NewSummarizer(config.Summarizer{Sentences: 1}, config.Extractor{})
+
+	articles := []*entity.Article{{Title: "Go", Body: summaryBody}}
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "Go programs compile quickly and the language has built-in concurrency."
+	if articles[0].Summary != expected || mockRepo.articles[0].Summary != expected {
+		t.Errorf("Expected summary %q, got %q", expected, mockRepo.articles[0].Summary)
+	}
+
+	article := &entity.Article{Title: "Go", Body: summaryBody}
+	summary, err := service.Summarize(context.Background(), article, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(summary) != 2 || article.Language != "en" {
+		t.Errorf("Expected 2 sentences in en, got %v in %s", summary, article.Language)
+	}
+	if len(mockRepo.articles) != 1 {
+		t.Errorf("Expected Summarize not to save, got %d articles", len(mockRepo.articles))
+	}
+
+	service.Summarizer = nil
+	if _, err := service.Summarize(context.Background(), article, 0); !errors.Is(err, ErrSummarizerDisabled) {
+		t.Errorf("Expected ErrSummarizerDisabled, got %v", err)
+	}
+}
@@ -1,44 +1,165 @@
 package app
 
 import (
+	"sort"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
 	"github.com/SaeedMPro/article-tag-extractor/utils"
 )
 
 type TagExtractorService struct {
+	analyzer analyzer
+	limits   TagLimits
 }
 
 func NewTagExtractorService() *TagExtractorService {
-	return &TagExtractorService{}
+	return NewTagExtractorServiceWithConfig(config.DefaultExtractor())
+}
+
+func NewTagExtractorServiceWithConfig(cfg config.Extractor) *TagExtractorService {
+	return &TagExtractorService{analyzer: newAnalyzer(cfg), limits: NewTagLimits(cfg)}
+}
+
+func (t *TagExtractorService) ExtractTags(doc entity.Document) []string {
+	return t.limits.Tags(t.ExtractScoredTags(doc))
+}
+
+func (t *TagExtractorService) tagLimits() TagLimits {
+	return t.limits
 }
 
-func (t *TagExtractorService) ExtractTags(title, body string) []string {
-	content := title + " " + body
+func (t *TagExtractorService) tagAnalyzer() analyzer {
+	return t.analyzer
+}
 
-	// simple tokenization by splitting on spaces and punctuation
-	tokens := utils.Tokenize(content)
+// ExtractScoredTags returns every candidate tag ranked by its field-weighted
+// frequency. Ties are broken by the position of the first occurrence and then
+// alphabetically, so the same input always yields the same order.
+func (t *TagExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	terms := countTerms(doc, t.analyzer)
 
-	// count word frequencies and filter stop words
-	wordCount := make(map[string]int)
-	for _, token := range tokens {
-		if !utils.IsStopWord(token) {
-			wordCount[token]++
+	ranked := make([]rankedTerm, 0, len(terms))
+	for _, tc := range terms {
+		ranked = append(ranked, rankedTerm{
+			term:      tc.term,
+			score:     tc.weight,
+			first:     tc.first,
+			frequency: tc.count,
+		})
+	}
+
+	return rankTerms(ranked)
+}
+
+// ExplainTags explains scoredTags by their field-weighted frequency in doc.
+func (t *TagExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explainTags(doc, t.analyzer, scoredTags, nil)
+}
+
+// termCount holds the number of occurrences of a term, their summed field
+// and position weights, the token position of its first occurrence and the
+// surface forms it was spelled in.
+type termCount struct {
+	term   string
+	count  int
+	weight float64
+	first  int
+	forms  surfaceForms
+}
+
+// countTerms tokenizes the fields of doc, drops the stop words of its language
+// and counts the remaining terms, merging words with the same stem when
+// stemming is enabled. Protected tokens are neither dropped nor stemmed.
+// Terms are returned in order of first appearance and named after their most
+// frequent surface form.
+func countTerms(doc entity.Document, a analyzer) []*termCount {
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	terms := []*termCount{}
+	index := make(map[string]*termCount)
+	offset := 0
+	for _, f := range a.weights.fields(doc) {
+		tokens := a.tokens(language, f.text)
+		for pos, token := range tokens {
+			if a.isStopWord(stopWords, doc.Tenant, language, token) {
+				continue
+			}
+			key := a.key(language, token)
+			tc, ok := index[key]
+			if !ok {
+				tc = &termCount{first: offset + pos}
+				index[key] = tc
+				terms = append(terms, tc)
+			}
+			tc.count++
+			tc.weight += a.weights.at(f, pos)
+			tc.forms.add(token.Text)
 		}
+		offset += len(tokens)
 	}
 
-	// convert to slice and sort by frequency
-	type wordFreq struct {
-		word  string
-		count int
+	for _, tc := range terms {
+		tc.term = tc.forms.best()
 	}
-	var wordFreqs []wordFreq
-	for word, count := range wordCount {
-		wordFreqs = append(wordFreqs, wordFreq{word, count})
+	return terms
+}
+
+type rankedTerm struct {
+	term       string
+	score      float64
+	first      int
+	entityType string
+	entityID   string
+	frequency  int
+}
+
+// rankTerms sorts terms by score, then first position, then alphabetically.
+func rankTerms(terms []rankedTerm) []entity.ScoredTag {
+	sort.Slice(terms, func(i, j int) bool {
+		a, b := terms[i], terms[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.first != b.first {
+			return a.first < b.first
+		}
+		return a.term < b.term
+	})
+
+	scoredTags := make([]entity.ScoredTag, 0, len(terms))
+	for _, rt := range terms {
+		scoredTags = append(scoredTags, entity.ScoredTag{
+			Tag:       rt.term,
+			Score:     rt.score,
+			Type:      rt.entityType,
+			ID:        rt.entityID,
+			Frequency: rt.frequency,
+		})
 	}
+	return scoredTags
+}
 
+// topTags returns the first n tags of a ranked list.
+func topTags(scoredTags []entity.ScoredTag, n int) []string {
 	tags := []string{}
-	for i := 0; i < 10 && i < len(wordFreqs); i++ {
-		tags = append(tags, wordFreqs[i].word)
+	for i := 0; i < n && i < len(scoredTags); i++ {
+		tags = append(tags, scoredTags[i].Tag)
 	}
-
 	return tags
 }
+
+// tagTokenizer protects the built-in technical terms, so "C++" and "C" stay
+// distinct tags.
+var tagTokenizer, _ = utils.NewProtectedTokenizer("")
+
+// normalizeTag normalizes a tag the way Tokenize normalizes text, so "Golang"
+// and "golang" compare equal. Arabic letter variants are folded to their
+// Persian forms, so a Persian tag matches however its letters are typed; the
+// tag itself keeps them. The tag is upper-cased before tokenizing because some
+// protected patterns, such as versioned names, only match capitalized words.
+func normalizeTag(tag string) string {
+	return strings.Join(tagTokenizer.Tokenize(utils.NormalizePersian(strings.ToUpper(tag))), " ")
+}
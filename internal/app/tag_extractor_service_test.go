@@ -1,9 +1,15 @@
 package app
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
 	"github.com/SaeedMPro/article-tag-extractor/utils"
 )
 
@@ -64,6 +70,18 @@ func TestTagExtractorService_Combined(t *testing.T) {
 			body:     "Go 1.21 introduced new features including generics and improved error handling.",
 			expected: 6,
 		},
+		{
+			name:     "Accented Latin content",
+			title:    "Überblick",
+			body:     "Die Größe der Städte wächst, während Dörfer schrumpfen.",
+			expected: 6,
+		},
+		{
+			name:     "Persian content",
+			title:    "يادگيري ماشين",
+			body:     "یادگیری ماشین شاخه‌ای از هوش مصنوعی است.",
+			expected: 5,
+		},
 		{
 			name:     "Very long content",
 			title:    "Long Article",
@@ -74,7 +92,7 @@ func TestTagExtractorService_Combined(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := extractor.ExtractTags(tt.title, tt.body)
+			tags := extractor.ExtractTags(entity.Document{Title: tt.title, Body: tt.body})
 
 			// Check minimum expected tags
 			if len(tags) < tt.expected {
@@ -105,3 +123,281 @@ func TestTagExtractorService_Combined(t *testing.T) {
 		})
 	}
 }
+
+func TestTagExtractorService_ExtractScoredTags_Ranking(t *testing.T) {
+	// flat field weights, so scores are plain counts and equal frequencies
+	extractor := NewTagExtractorServiceWithConfig(config.Extractor{TitleWeight: 1})
+
+	title := "Kubernetes Operators"
+	body := "Operators extend kubernetes. Operators manage state, and kubernetes schedules pods. Helm charts package pods."
+
+	scoredTags := extractor.ExtractScoredTags(entity.Document{Title: title, Body: body})
+	expected := []entity.ScoredTag{
+		{Tag: "kubernetes", Score: 3, Frequency: 3},
+		{Tag: "operators", Score: 3, Frequency: 3},
+		{Tag: "pods", Score: 2, Frequency: 2},
+		{Tag: "extend", Score: 1, Frequency: 1},
+		{Tag: "manage", Score: 1, Frequency: 1},
+		{Tag: "state", Score: 1, Frequency: 1},
+		{Tag: "schedules", Score: 1, Frequency: 1},
+		{Tag: "helm", Score: 1, Frequency: 1},
+		{Tag: "charts", Score: 1, Frequency: 1},
+		{Tag: "package", Score: 1, Frequency: 1},
+	}
+
+	if len(scoredTags) != len(expected) {
+		t.Fatalf("Expected %d scored tags, got %d: %v", len(expected), len(scoredTags), scoredTags)
+	}
+	for i, scoredTag := range scoredTags {
+		if scoredTag != expected[i] {
+			t.Errorf("Expected tag %d to be %v, got %v", i, expected[i], scoredTag)
+		}
+	}
+}
+
+func TestTagExtractorService_ExtractTags_Deterministic(t *testing.T) {
+	extractor := NewTagExtractorService()
+
+	title := "Distributed Systems"
+	body := "Consensus, replication, partitioning, sharding, leader election, gossip, quorum, " +
+		"clocks, snapshots, logs, compaction, membership and failure detection are core topics."
+
+	first := extractor.ExtractTags(entity.Document{Title: title, Body: body})
+	if len(first) != 10 {
+		t.Fatalf("Expected 10 tags, got %d", len(first))
+	}
+
+	for run := 0; run < 50; run++ {
+		tags := extractor.ExtractTags(entity.Document{Title: title, Body: body})
+		if !reflect.DeepEqual(tags, first) {
+			t.Fatalf("Run %d: expected %v, got %v", run, first, tags)
+		}
+	}
+
+	// every tag appears once, so the order follows the text
+	expected := []string{"distributed", "systems", "consensus", "replication", "partitioning",
+		"sharding", "leader", "election", "gossip", "quorum"}
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("Expected %v, got %v", expected, first)
+	}
+}
+
+func TestTagExtractorService_LanguageStopWords(t *testing.T) {
+	extractor := NewTagExtractorService()
+
+	tests := []struct {
+		name      string
+		title     string
+		body      string
+		stopWords []string
+	}{
+		{
+			name:      "German article",
+			title:     "Die Zukunft der Energie",
+			body:      "Die Regierung hat einen Plan, und die Bürger wollen, dass die Preise für Strom und Gas sinken.",
+			stopWords: []string{"die", "der", "und", "dass", "hat", "für"},
+		},
+		{
+			name:      "Spanish article",
+			title:     "El futuro de la energía",
+			body:      "El gobierno tiene un plan para que los precios de la luz y del gas bajen en los próximos años.",
+			stopWords: []string{"el", "de", "la", "los", "para", "que", "del"},
+		},
+		{
+			name:      "Persian article",
+			title:     "آینده انرژی",
+			body:      "دولت برای کاهش قیمت برق و گاز در سال‌های آینده یک برنامه جدید دارد و این برنامه را اجرا می‌کند.",
+			stopWords: []string{"برای", "و", "در", "یک", "دارد", "این", "را"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := extractor.ExtractTags(entity.Document{Title: tt.title, Body: tt.body})
+			if len(tags) == 0 {
+				t.Fatal("Expected tags, got none")
+			}
+			for _, tag := range tags {
+				for _, stopWord := range tt.stopWords {
+					if tag == stopWord {
+						t.Errorf("Tag '%s' is a stop word, tags: %v", tag, tags)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTagExtractorService_ScriptLetters(t *testing.T) {
+	extractor := NewTagExtractorService()
+
+	tests := []struct {
+		name     string
+		doc      entity.Document
+		expected []string
+	}{
+		{
+			name: "Arabic article keeps its letters",
+			doc: entity.Document{
+				Title: "المدرسة العربية",
+				Body:  "افتتحت المدرسة العربية الكبيرة في المدينة، وتعلم المدرسة العربية الطلاب اللغة العربية.",
+			},
+			expected: []string{"المدرسة", "العربية"},
+		},
+		{
+			name: "Persian article typed with Arabic letters",
+			doc: entity.Document{
+				Title:    "كتابخانه ملي",
+				Body:     "كتابخانه ملي ايران كتاب‌هاي تاريخي را نگهداري مي‌كند و كتابخانه ملي براي پژوهشگران باز است.",
+				Language: "fa",
+			},
+			expected: []string{"کتابخانه", "ملی"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := extractor.ExtractTags(tt.doc)
+			for _, want := range tt.expected {
+				if !slices.Contains(tags, want) {
+					t.Errorf("Expected tag '%s', got %v", want, tags)
+				}
+			}
+		})
+	}
+}
+
+func TestTagExtractorService_Stemming(t *testing.T) {
+	title := "Running every day"
+	body := "Running is a habit. She runs in the park, and the kids run with her. Running shoes matter."
+
+	t.Run("disabled", func(t *testing.T) {
+		scoredTags := NewTagExtractorService().ExtractScoredTags(entity.Document{Title: title, Body: body})
+
+		found := make(map[string]float64)
+		for _, st := range scoredTags {
+			found[st.Tag] = st.Score
+		}
+		if found["running"] != 4 || found["runs"] != 1 || found["run"] != 1 {
+			t.Errorf("Expected separate counts for running, runs and run, got %v", scoredTags)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		extractor := NewTagExtractorServiceWithConfig(config.Extractor{Stemming: true})
+		scoredTags := extractor.ExtractScoredTags(entity.Document{Title: title, Body: body})
+
+		// counts merge under the stem but the most frequent surface form is
+		// emitted; the title occurrence weighs 2
+		if len(scoredTags) == 0 || scoredTags[0] != (entity.ScoredTag{Tag: "running", Score: 6, Frequency: 5}) {
+			t.Errorf("Expected {running 6} to rank first, got %v", scoredTags)
+		}
+		for _, st := range scoredTags {
+			if st.Tag == "runs" || st.Tag == "run" {
+				t.Errorf("Expected '%s' to be merged into 'running', got %v", st.Tag, scoredTags)
+			}
+		}
+	})
+
+	t.Run("persian", func(t *testing.T) {
+		extractor := NewTagExtractorServiceWithConfig(config.Extractor{Stemming: true})
+		scoredTags := extractor.ExtractScoredTags(entity.Document{Title: "کتاب‌ها", Body: "این کتاب‌ها را خواندم. کتاب خوبی بود و کتاب‌های دیگر هم خوب بودند."})
+
+		if len(scoredTags) == 0 || scoredTags[0] != (entity.ScoredTag{Tag: "کتاب‌ها", Score: 5, Frequency: 4}) {
+			t.Errorf("Expected {کتاب‌ها 5} to rank first, got %v", scoredTags)
+		}
+	})
+}
+
+func TestTagExtractorService_ProtectedTokens(t *testing.T) {
+	doc := entity.Document{
+		Title: "COVID-19 tracking in C++",
+		Body:  "The tracker is written in C++ and exposes an IPv6 API. COVID-19 data arrives hourly.",
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		tags := NewTagExtractorService().ExtractTags(doc)
+		for _, tag := range tags {
+			if tag == "c++" || tag == "covid-19" {
+				t.Errorf("Expected technical terms to be split without protection, got %v", tags)
+			}
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		extractor := NewTagExtractorServiceWithConfig(config.Extractor{ProtectedTokens: true, Stemming: true})
+		scoredTags := extractor.ExtractScoredTags(doc)
+
+		scores := make(map[string]float64)
+		for _, st := range scoredTags {
+			scores[st.Tag] = st.Score
+		}
+		// title 2 + body 1 each; protected tokens are not stemmed
+		if scores["covid-19"] != 3 || scores["c++"] != 3 || scores["ipv6"] != 1 {
+			t.Errorf("Expected protected terms to be counted whole, got %v", scoredTags)
+		}
+		if _, ok := scores["covid"]; ok {
+			t.Errorf("Expected no partial terms, got %v", scoredTags)
+		}
+	})
+
+	t.Run("stop words", func(t *testing.T) {
+		// "it" is a stop word, "IT-2" is protected
+		extractor := NewTagExtractorServiceWithConfig(config.Extractor{ProtectedTokens: true, TitleWeight: 1})
+		tags := extractor.ExtractTags(entity.Document{Body: "It ships the IT-2 board"})
+		if !reflect.DeepEqual(tags, []string{"ships", "it-2", "board"}) {
+			t.Errorf("Expected [ships it-2 board], got %v", tags)
+		}
+	})
+
+	t.Run("user patterns", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "patterns.txt")
+		if err := os.WriteFile(path, []byte("(?i:web\\s?3)\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		extractor := NewTagExtractorServiceWithConfig(config.Extractor{ProtectedTokens: true, ProtectedPatternsFile: path})
+		tags := extractor.ExtractTags(entity.Document{Body: "Web 3 wallets"})
+		if !reflect.DeepEqual(tags, []string{"web 3", "wallets"}) {
+			t.Errorf("Expected [web 3 wallets], got %v", tags)
+		}
+	})
+}
+
+func TestTagExtractorService_FieldWeights(t *testing.T) {
+	doc := entity.Document{
+		Title:   "Quantum networking",
+		Summary: "A look at entanglement.",
+		Body:    "Researchers compared routers and routers again. Later sections mention quantum repeaters and entanglement.",
+	}
+
+	t.Run("title and summary boost", func(t *testing.T) {
+		scoredTags := NewTagExtractorService().ExtractScoredTags(doc)
+
+		scores := make(map[string]float64)
+		for _, st := range scoredTags {
+			scores[st.Tag] = st.Score
+		}
+		// title 2 + body 1, summary 1.5 + body 1, body 1 + body 1
+		if scores["quantum"] != 3 || scores["entanglement"] != 2.5 || scores["routers"] != 2 {
+			t.Errorf("Expected weighted scores, got %v", scoredTags)
+		}
+		if scoredTags[0].Tag != "quantum" {
+			t.Errorf("Expected the title term to rank first, got %v", scoredTags)
+		}
+	})
+
+	t.Run("position decay", func(t *testing.T) {
+		extractor := NewTagExtractorServiceWithConfig(config.Extractor{PositionHalfLife: 2})
+		scoredTags := extractor.ExtractScoredTags(entity.Document{
+			Body: "Researchers compared routers first. Much later, the repeaters were compared.",
+		})
+
+		scores := make(map[string]float64)
+		for _, st := range scoredTags {
+			scores[st.Tag] = st.Score
+		}
+		if scores["routers"] <= scores["repeaters"] {
+			t.Errorf("Expected the early term to outscore the late one, got %v", scoredTags)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"errors"
+	"math"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// defaultMaxTags is the number of tags kept for an article unless configured
+// otherwise.
+const defaultMaxTags = 10
+
+// TagLimits bounds the tags kept for an article. A zero MaxTags keeps
+// defaultMaxTags; the other zero limits keep every tag.
+type TagLimits struct {
+	MaxTags int
+	// MinFrequency drops tags occurring fewer times in the document. Tags of
+	// extractors that do not count occurrences are kept.
+	MinFrequency int
+	// MinScore drops tags scoring below this fraction, from 0 to 1, of the
+	// top score, so it means the same for every extractor.
+	MinScore float64
+}
+
+// NewTagLimits returns the limits configured for the extractors.
+func NewTagLimits(cfg config.Extractor) TagLimits {
+	return TagLimits{
+		MaxTags:      cfg.MaxTags,
+		MinFrequency: cfg.MinFrequency,
+		MinScore:     cfg.MinScore,
+	}
+}
+
+// Validate reports limits that are out of range.
+func (l TagLimits) Validate() error {
+	switch {
+	case l.MaxTags < 0:
+		return errors.New("max tags cannot be negative")
+	case l.MinFrequency < 0:
+		return errors.New("min frequency cannot be negative")
+	case l.MinScore < 0 || l.MinScore > 1 || math.IsNaN(l.MinScore):
+		return errors.New("min score must be between 0 and 1")
+	}
+	return nil
+}
+
+// Override returns l with the limits that are set in o replaced.
+func (l TagLimits) Override(o TagLimits) TagLimits {
+	if o.MaxTags > 0 {
+		l.MaxTags = o.MaxTags
+	}
+	if o.MinFrequency > 0 {
+		l.MinFrequency = o.MinFrequency
+	}
+	if o.MinScore > 0 {
+		l.MinScore = o.MinScore
+	}
+	return l
+}
+
+func (l TagLimits) maxTags() int {
+	if l.MaxTags <= 0 {
+		return defaultMaxTags
+	}
+	return l.MaxTags
+}
+
+// Filter returns the ranked tags that are frequent enough and score high
+// enough, keeping their order. MaxTags is not applied, as tag policies may
+// still reject some of the tags.
+func (l TagLimits) Filter(scoredTags []entity.ScoredTag) []entity.ScoredTag {
+	if l.MinFrequency <= 1 && l.MinScore <= 0 {
+		return scoredTags
+	}
+
+	top := 0.0
+	for _, st := range scoredTags {
+		top = max(top, st.Score)
+	}
+
+	kept := []entity.ScoredTag{}
+	for _, st := range scoredTags {
+		if st.Frequency > 0 && st.Frequency < l.MinFrequency {
+			continue
+		}
+		if l.MinScore > 0 && (top <= 0 || st.Score/top < l.MinScore) {
+			continue
+		}
+		kept = append(kept, st)
+	}
+	return kept
+}
+
+// Tags returns the top tags within the limits.
+func (l TagLimits) Tags(scoredTags []entity.ScoredTag) []string {
+	return topTags(l.Filter(scoredTags), l.maxTags())
+}
+
+// limitedExtractor is implemented by extractors that keep their tags within
+// configured limits, so decorators wrapping them can keep the same limits.
+type limitedExtractor interface {
+	tagLimits() TagLimits
+}
+
+// extractorLimits returns the limits of extractor, or the default limits if
+// it has none.
+func extractorLimits(extractor port.TagExtractor) TagLimits {
+	if limited, ok := extractor.(limitedExtractor); ok {
+		return limited.tagLimits()
+	}
+	return TagLimits{}
+}
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+func TestTagLimits_Filter(t *testing.T) {
+	scoredTags := []entity.ScoredTag{
+		{Tag: "kubernetes", Score: 10, Frequency: 5},
+		{Tag: "operators", Score: 6, Frequency: 3},
+		// from an extractor that does not count occurrences
+		{Tag: "cloud native", Score: 4},
+		{Tag: "helm", Score: 2, Frequency: 1},
+	}
+
+	tests := []struct {
+		name     string
+		limits   TagLimits
+		expected []string
+	}{
+		{name: "no limits", limits: TagLimits{}, expected: []string{"kubernetes", "operators", "cloud native", "helm"}},
+		{name: "min frequency", limits: TagLimits{MinFrequency: 3}, expected: []string{"kubernetes", "operators", "cloud native"}},
+		{name: "min score", limits: TagLimits{MinScore: 0.5}, expected: []string{"kubernetes", "operators"}},
+		{name: "both", limits: TagLimits{MinFrequency: 4, MinScore: 0.3}, expected: []string{"kubernetes", "cloud native"}},
+		{name: "max tags", limits: TagLimits{MaxTags: 2, MinScore: 0.1}, expected: []string{"kubernetes", "operators"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tags := tt.limits.Tags(scoredTags); !reflect.DeepEqual(tags, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tags)
+			}
+		})
+	}
+}
+
+func TestTagLimits_Override(t *testing.T) {
+	limits := TagLimits{MaxTags: 10, MinFrequency: 2, MinScore: 0.1}
+
+	result := limits.Override(TagLimits{MaxTags: 3, MinScore: 0.5})
+	expected := TagLimits{MaxTags: 3, MinFrequency: 2, MinScore: 0.5}
+	if result != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestTagLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits TagLimits
+		valid  bool
+	}{
+		{name: "defaults", limits: TagLimits{}, valid: true},
+		{name: "all set", limits: TagLimits{MaxTags: 5, MinFrequency: 2, MinScore: 1}, valid: true},
+		{name: "negative max tags", limits: TagLimits{MaxTags: -1}},
+		{name: "negative min frequency", limits: TagLimits{MinFrequency: -1}},
+		{name: "min score above 1", limits: TagLimits{MinScore: 1.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Expected valid %v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+func TestTagExtractorService_ExtractTags_Limits(t *testing.T) {
+	doc := entity.Document{
+		Title: "Kubernetes Operators",
+		Body:  "Operators extend kubernetes. Operators manage state, and kubernetes schedules pods. Helm charts package pods.",
+	}
+
+	cfg := config.DefaultExtractor()
+	cfg.MaxTags = 3
+	if tags := NewTagExtractorServiceWithConfig(cfg).ExtractTags(doc); len(tags) != 3 {
+		t.Errorf("Expected 3 tags, got %v", tags)
+	}
+
+	cfg = config.DefaultExtractor()
+	cfg.MinFrequency = 2
+	tags := NewTagExtractorServiceWithConfig(cfg).ExtractTags(doc)
+	if !reflect.DeepEqual(tags, []string{"kubernetes", "operators", "pods"}) {
+		t.Errorf("Expected only words occurring twice, got %v", tags)
+	}
+}
+
+func TestDecorators_ExtractTags_Limits(t *testing.T) {
+	doc := entity.Document{
+		Title: "Kubernetes Operators at NASA",
+		Body: "Operators extend kubernetes. Operators manage state, and kubernetes schedules pods. " +
+			"Helm charts package pods. NASA runs clusters, storage, networking, monitoring and logging.",
+	}
+	cfg := config.DefaultExtractor()
+	cfg.MaxTags = 3
+	cfg.MinFrequency = 2
+
+	registry := NewExtractorRegistry(RakeExtractor)
+	registry.Register(RakeExtractor, "1", NewRakeExtractorService(cfg))
+	registry.Register(FrequencyExtractor, "1", NewTagExtractorServiceWithConfig(cfg))
+	ensemble, err := NewEnsembleExtractorService(registry, config.Ensemble{Weights: map[string]float64{FrequencyExtractor: 1, RakeExtractor: 1}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	base := NewTagExtractorServiceWithConfig(cfg)
+	extractors := map[string]port.TagExtractor{
+		"ensemble":  ensemble,
+		"entity":    NewEntityExtractorService(base, config.Entities{}),
+		"gazetteer": NewGazetteerExtractorService(base, NewGazetteer(nil), config.Gazetteer{}),
+		"taxonomy":  NewTaxonomyExtractorService(base, nil, config.Taxonomy{}),
+		"feedback":  NewFeedbackExtractorService(base, nil, config.Feedback{}),
+		"nested":    NewFeedbackExtractorService(NewEntityExtractorService(base, config.Entities{}), nil, config.Feedback{}),
+	}
+	for name, extractor := range extractors {
+		if tags := extractor.ExtractTags(doc); len(tags) != 3 {
+			t.Errorf("%s: expected 3 tags, got %v", name, tags)
+		}
+	}
+
+	// without limits of its own, the wrapped extractor keeps the default
+	scoredTags := []entity.ScoredTag{}
+	for i := range 20 {
+		scoredTags = append(scoredTags, entity.ScoredTag{Tag: fmt.Sprintf("tag%d", i), Score: float64(20 - i)})
+	}
+	wrapped := NewEntityExtractorService(&scoredTagExtractor{scoredTags: scoredTags}, config.Entities{})
+	if tags := wrapped.ExtractTags(doc); len(tags) != defaultMaxTags {
+		t.Errorf("Expected %d tags, got %v", defaultMaxTags, tags)
+	}
+}
+
+func TestArticleService_ProcessArticles_Limits(t *testing.T) {
+	extractor := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "kubernetes", Score: 10, Frequency: 5},
+		{Tag: "operators", Score: 6, Frequency: 3},
+		{Tag: "helm", Score: 2, Frequency: 1},
+	}}
+	articles := func() []*entity.Article {
+		return []*entity.Article{{Title: "Kubernetes", Body: "Operators"}}
+	}
+
+	mockRepo := &MockArticleRepository{}
+	service := NewArticleServiceWithExtractor(mockRepo, extractor)
+	service.Limits = TagLimits{MinFrequency: 2}
+	if _, err := service.ProcessArticles(t.Context(), articles()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tags := mockRepo.articles[0].Tags; !reflect.DeepEqual(tags, []string{"kubernetes", "operators"}) {
+		t.Errorf("Expected the configured limits to apply, got %v", tags)
+	}
+
+	mockRepo.articles = nil
+	if _, err := service.ProcessArticlesWithOptions(t.Context(), articles(), ProcessOptions{MinFrequency: 4}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tags := mockRepo.articles[0].Tags; !reflect.DeepEqual(tags, []string{"kubernetes"}) {
+		t.Errorf("Expected the request to override the limits, got %v", tags)
+	}
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// Reasons recorded for rejected tags.
+const (
+	RejectNotAllowed  = "not in allowlist"
+	RejectBlocked     = "blocklisted"
+	RejectDenyPattern = "matches deny pattern"
+	RejectTooShort    = "shorter than min length"
+	RejectTooLong     = "longer than max length"
+	RejectProfanity   = "profanity"
+)
+
+// TagPolicyRules is the JSON form of a single tag policy. Empty fields do not
+// restrict anything.
+type TagPolicyRules struct {
+	Allow        []string `json:"allow"`
+	Block        []string `json:"block"`
+	DenyPatterns []string `json:"deny_patterns"`
+	MinLength    int      `json:"min_length"`
+	MaxLength    int      `json:"max_length"`
+	Profanity    []string `json:"profanity"`
+}
+
+// TagPolicyConfig is the JSON form of a policy file: a default policy and
+// policies selected by tenant or source.
+type TagPolicyConfig struct {
+	Default TagPolicyRules            `json:"default"`
+	Tenants map[string]TagPolicyRules `json:"tenants"`
+	Sources map[string]TagPolicyRules `json:"sources"`
+}
+
+// TagPolicy decides which extracted tags may be saved on an article.
+type TagPolicy struct {
+	allow        map[string]bool
+	block        map[string]bool
+	denyPatterns []*regexp.Regexp
+	minLength    int
+	maxLength    int
+	profanity    map[string]bool
+}
+
+// TagPolicies holds the compiled policies of a policy file.
+type TagPolicies struct {
+	defaultPolicy *TagPolicy
+	tenants       map[string]*TagPolicy
+	sources       map[string]*TagPolicy
+}
+
+// LoadTagPolicies reads and compiles the policy file at path.
+func LoadTagPolicies(path string) (*TagPolicies, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg TagPolicyConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse tag policy file %s: %w", path, err)
+	}
+	return NewTagPolicies(cfg)
+}
+
+// NewTagPolicies compiles cfg. It fails on invalid deny patterns.
+func NewTagPolicies(cfg TagPolicyConfig) (*TagPolicies, error) {
+	defaultPolicy, err := NewTagPolicy(cfg.Default)
+	if err != nil {
+		return nil, fmt.Errorf("default policy: %w", err)
+	}
+
+	p := &TagPolicies{
+		defaultPolicy: defaultPolicy,
+		tenants:       make(map[string]*TagPolicy, len(cfg.Tenants)),
+		sources:       make(map[string]*TagPolicy, len(cfg.Sources)),
+	}
+	for tenant, rules := range cfg.Tenants {
+		if p.tenants[tenant], err = NewTagPolicy(rules); err != nil {
+			return nil, fmt.Errorf("policy of tenant %s: %w", tenant, err)
+		}
+	}
+	for source, rules := range cfg.Sources {
+		if p.sources[source], err = NewTagPolicy(rules); err != nil {
+			return nil, fmt.Errorf("policy of source %s: %w", source, err)
+		}
+	}
+	return p, nil
+}
+
+// NewTagPolicy compiles a single policy.
+func NewTagPolicy(rules TagPolicyRules) (*TagPolicy, error) {
+	p := &TagPolicy{
+		allow:     tagSet(rules.Allow),
+		block:     tagSet(rules.Block),
+		minLength: rules.MinLength,
+		maxLength: rules.MaxLength,
+		profanity: tagSet(rules.Profanity),
+	}
+	for _, pattern := range rules.DenyPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("deny pattern %q: %w", pattern, err)
+		}
+		p.denyPatterns = append(p.denyPatterns, re)
+	}
+	return p, nil
+}
+
+func tagSet(tags []string) map[string]bool {
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if key := normalizeTag(tag); key != "" {
+			set[key] = true
+		}
+	}
+	return set
+}
+
+// Select returns the policy of source if there is one, else the policy of
+// tenant, else the default policy. A nil TagPolicies selects no policy.
+func (p *TagPolicies) Select(tenant, source string) *TagPolicy {
+	if p == nil {
+		return nil
+	}
+	if policy, ok := p.sources[source]; ok && source != "" {
+		return policy
+	}
+	if policy, ok := p.tenants[tenant]; ok && tenant != "" {
+		return policy
+	}
+	return p.defaultPolicy
+}
+
+// Check returns the reason tag is rejected, or "" if it is accepted. A nil
+// policy accepts every tag.
+func (p *TagPolicy) Check(tag string) string {
+	if p == nil {
+		return ""
+	}
+
+	key := normalizeTag(tag)
+	if len(p.allow) > 0 && !p.allow[key] {
+		return RejectNotAllowed
+	}
+	if p.block[key] {
+		return RejectBlocked
+	}
+	for _, re := range p.denyPatterns {
+		if re.MatchString(tag) {
+			return fmt.Sprintf("%s %s", RejectDenyPattern, re)
+		}
+	}
+	length := utf8.RuneCountInString(tag)
+	if p.minLength > 0 && length < p.minLength {
+		return fmt.Sprintf("%s %d", RejectTooShort, p.minLength)
+	}
+	if p.maxLength > 0 && length > p.maxLength {
+		return fmt.Sprintf("%s %d", RejectTooLong, p.maxLength)
+	}
+	for _, word := range strings.Fields(key) {
+		if p.profanity[word] {
+			return RejectProfanity
+		}
+	}
+	if p.profanity[key] {
+		return RejectProfanity
+	}
+	return ""
+}
+
+// SelectTags walks scoredTags in rank order and returns the first n tags the
+// policy accepts, together with the tags it rejected on the way.
+func (p *TagPolicy) SelectTags(scoredTags []entity.ScoredTag, n int) ([]string, []entity.RejectedTag) {
+	tags := []string{}
+	var rejected []entity.RejectedTag
+	for _, st := range scoredTags {
+		if len(tags) >= n {
+			break
+		}
+		if reason := p.Check(st.Tag); reason != "" {
+			rejected = append(rejected, entity.RejectedTag{Tag: st.Tag, Reason: reason})
+			continue
+		}
+		tags = append(tags, st.Tag)
+	}
+	return tags, rejected
+}
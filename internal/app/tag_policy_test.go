@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestTagPolicy_Check(t *testing.T) {
+	policy, err := NewTagPolicy(TagPolicyRules{
+		Block:        []string{"Click Here"},
+		DenyPatterns: []string{`^\d+$`},
+		MinLength:    3,
+		MaxLength:    20,
+		Profanity:    []string{"damn"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		tag      string
+		expected string
+	}{
+		{name: "Accepted", tag: "kubernetes", expected: ""},
+		{name: "Blocklisted", tag: "click here", expected: RejectBlocked},
+		{name: "Deny pattern", tag: "2024", expected: RejectDenyPattern + ` ^\d+$`},
+		{name: "Too short", tag: "ai", expected: RejectTooShort + " 3"},
+		{name: "Too long", tag: "extraordinarily verbose phrase", expected: RejectTooLong + " 20"},
+		{name: "Length counts runes", tag: "کتاب", expected: ""},
+		{name: "Profanity in phrase", tag: "damn bugs", expected: RejectProfanity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason := policy.Check(tt.tag); reason != tt.expected {
+				t.Errorf("Expected reason %q, got %q", tt.expected, reason)
+			}
+		})
+	}
+}
+
+func TestTagPolicy_Allowlist(t *testing.T) {
+	policy, err := NewTagPolicy(TagPolicyRules{Allow: []string{"Go", "rust"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if reason := policy.Check("go"); reason != "" {
+		t.Errorf("Expected 'go' to be allowed, got %q", reason)
+	}
+	if reason := policy.Check("java"); reason != RejectNotAllowed {
+		t.Errorf("Expected %q, got %q", RejectNotAllowed, reason)
+	}
+}
+
+func TestTagPolicy_SelectTags(t *testing.T) {
+	policy, err := NewTagPolicy(TagPolicyRules{Block: []string{"news"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	scoredTags := []entity.ScoredTag{
+		{Tag: "news", Score: 5},
+		{Tag: "go", Score: 4},
+		{Tag: "rust", Score: 3},
+		{Tag: "zig", Score: 2},
+	}
+
+	// a rejected tag makes room for the next candidate
+	tags, rejected := policy.SelectTags(scoredTags, 2)
+	if !reflect.DeepEqual(tags, []string{"go", "rust"}) {
+		t.Errorf("Expected [go rust], got %v", tags)
+	}
+	if !reflect.DeepEqual(rejected, []entity.RejectedTag{{Tag: "news", Reason: RejectBlocked}}) {
+		t.Errorf("Expected 'news' to be rejected, got %v", rejected)
+	}
+
+	var none *TagPolicy
+	if tags, rejected := none.SelectTags(scoredTags, 10); len(tags) != 4 || rejected != nil {
+		t.Errorf("Expected a nil policy to accept every tag, got %v and %v", tags, rejected)
+	}
+}
+
+func TestTagPolicies_Select(t *testing.T) {
+	policies, err := NewTagPolicies(TagPolicyConfig{
+		Default: TagPolicyRules{Block: []string{"default"}},
+		Tenants: map[string]TagPolicyRules{"acme": {Block: []string{"tenant"}}},
+		Sources: map[string]TagPolicyRules{"wire": {Block: []string{"source"}}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		tenant  string
+		source  string
+		blocked string
+	}{
+		{name: "Default", blocked: "default"},
+		{name: "Unknown tenant", tenant: "globex", blocked: "default"},
+		{name: "Tenant", tenant: "acme", blocked: "tenant"},
+		{name: "Source", source: "wire", blocked: "source"},
+		{name: "Source over tenant", tenant: "acme", source: "wire", blocked: "source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason := policies.Select(tt.tenant, tt.source).Check(tt.blocked); reason != RejectBlocked {
+				t.Errorf("Expected '%s' to be blocked, got %q", tt.blocked, reason)
+			}
+		})
+	}
+
+	var none *TagPolicies
+	if policy := none.Select("acme", "wire"); policy != nil {
+		t.Errorf("Expected no policy, got %v", policy)
+	}
+}
+
+func TestLoadTagPolicies(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"default": {"min_length": 3}, "tenants": {"acme": {"block": ["acme"]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policies, err := LoadTagPolicies(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reason := policies.Select("", "").Check("ai"); reason != RejectTooShort+" 3" {
+		t.Errorf("Expected the default policy to apply, got %q", reason)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"sources": {"wire": {"deny_patterns": ["("]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTagPolicies(invalid); err == nil {
+		t.Error("Expected an error for an invalid deny pattern")
+	}
+
+	if _, err := LoadTagPolicies(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestArticleService_ProcessArticles_TagPolicies(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	service := NewArticleServiceWithExtractor(mockRepo, &MockTagExtractor{tags: []string{"breaking", "go", "ai"}})
+
+	policies, err := NewTagPolicies(TagPolicyConfig{
+		Default: TagPolicyRules{MinLength: 3},
+		Sources: map[string]TagPolicyRules{"wire": {Block: []string{"breaking"}, MinLength: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	service.TagPolicies = policies
+
+	articles := []*entity.Article{{Title: "Wire story", Body: "Body", Source: "wire"}}
+	if _, err := service.ProcessArticles(context.Background(), articles); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(mockRepo.articles) != 1 {
+		t.Fatalf("Expected 1 saved article, got %d", len(mockRepo.articles))
+	}
+	saved := mockRepo.articles[0]
+	if !reflect.DeepEqual(saved.Tags, []string{"go", "ai"}) {
+		t.Errorf("Expected tags [go ai], got %v", saved.Tags)
+	}
+	if !reflect.DeepEqual(saved.RejectedTags, []entity.RejectedTag{{Tag: "breaking", Reason: RejectBlocked}}) {
+		t.Errorf("Expected 'breaking' to be rejected, got %v", saved.RejectedTags)
+	}
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// TaxonomyExtractorService maps the tags of another extractor onto the
+// controlled vocabulary, so spelling variants such as "golang" are emitted as
+// their canonical tag "go". The vocabulary is read from an in-memory snapshot
+// that is swapped atomically on refresh.
+type TaxonomyExtractorService struct {
+	extractor port.TagExtractor
+	source    port.VocabularyRepository
+	canonical *atomic.Pointer[map[string]string]
+	enforce   bool
+}
+
+func NewTaxonomyExtractorService(extractor port.TagExtractor, source port.VocabularyRepository, cfg config.Taxonomy) *TaxonomyExtractorService {
+	t := &TaxonomyExtractorService{
+		extractor: extractor,
+		source:    source,
+		canonical: &atomic.Pointer[map[string]string]{},
+		enforce:   cfg.Enforce,
+	}
+	t.canonical.Store(&map[string]string{})
+	return t
+}
+
+// Wrap returns a service that maps the tags of extractor onto the same
+// vocabulary snapshot, so refreshing t refreshes both.
+func (t *TaxonomyExtractorService) Wrap(extractor port.TagExtractor) *TaxonomyExtractorService {
+	return &TaxonomyExtractorService{
+		extractor: extractor,
+		source:    t.source,
+		canonical: t.canonical,
+		enforce:   t.enforce,
+	}
+}
+
+// Refresh loads a new vocabulary snapshot from the repository. An empty
+// vocabulary is logged when it is enforced, as tags are then kept free.
+func (t *TaxonomyExtractorService) Refresh(ctx context.Context) error {
+	terms, err := t.source.GetVocabulary(ctx)
+	if err != nil {
+		return err
+	}
+	if len(terms) == 0 && t.enforce {
+		log.Printf("warning: the enforced vocabulary is empty, keeping free tags until terms are imported")
+	}
+
+	canonical := make(map[string]string)
+	for _, term := range terms {
+		canonical[normalizeTag(term.Tag)] = term.Tag
+	}
+	// an alias never overrides a canonical tag
+	for _, term := range terms {
+		for _, alias := range term.Aliases {
+			if key := normalizeTag(alias); key != "" {
+				if _, ok := canonical[key]; !ok {
+					canonical[key] = term.Tag
+				}
+			}
+		}
+	}
+	t.canonical.Store(&canonical)
+	return nil
+}
+
+// StartRefresh refreshes the snapshot immediately and then on every interval
+// until ctx is cancelled.
+func (t *TaxonomyExtractorService) StartRefresh(ctx context.Context, interval time.Duration) {
+	if err := t.Refresh(ctx); err != nil {
+		log.Printf("failed to refresh vocabulary: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.Refresh(ctx); err != nil {
+				log.Printf("failed to refresh vocabulary: %v", err)
+			}
+		}
+	}
+}
+
+func (t *TaxonomyExtractorService) ExtractTags(doc entity.Document) []string {
+	return t.tagLimits().Tags(t.ExtractScoredTags(doc))
+}
+
+// tagLimits returns the limits of the wrapped extractor.
+func (t *TaxonomyExtractorService) tagLimits() TagLimits {
+	return extractorLimits(t.extractor)
+}
+
+// tagAnalyzer returns the analyzer of the wrapped extractor.
+func (t *TaxonomyExtractorService) tagAnalyzer() analyzer {
+	return extractorAnalyzer(t.extractor)
+}
+
+// ExplainTags explains scoredTags with the wrapped extractor. Canonical tags
+// only show the occurrences of the canonical spelling.
+func (t *TaxonomyExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explain(t.extractor, doc, scoredTags)
+}
+
+// ExtractScoredTags canonicalizes the tags of the wrapped extractor. Tags that
+// map to the same canonical tag are merged and their scores and frequencies
+// added, keeping the entity type of the first that has one. Tags outside the
+// vocabulary are dropped when the vocabulary is enforced, unless it is empty:
+// an enforced vocabulary that was never imported would drop every tag.
+func (t *TaxonomyExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	canonical := *t.canonical.Load()
+	enforce := t.enforce && len(canonical) > 0
+
+	ranked := []rankedTerm{}
+	index := make(map[string]int)
+	for pos, st := range t.extractor.ExtractScoredTags(doc) {
+		tag, ok := canonical[normalizeTag(st.Tag)]
+		if !ok {
+			if enforce {
+				continue
+			}
+			tag = st.Tag
+		}
+
+		if i, ok := index[tag]; ok {
+			ranked[i].score += st.Score
+			ranked[i].frequency += st.Frequency
+			if ranked[i].entityType == "" {
+				ranked[i].entityType, ranked[i].entityID = st.Type, st.ID
+			}
+			continue
+		}
+		index[tag] = len(ranked)
+		ranked = append(ranked, rankedTerm{term: tag, score: st.Score, first: pos, entityType: st.Type, entityID: st.ID, frequency: st.Frequency})
+	}
+
+	return rankTerms(ranked)
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// MockVocabularyRepository is a mock implementation of VocabularyRepository
+type MockVocabularyRepository struct {
+	terms []entity.VocabularyTerm
+	err   error
+}
+
+func (m *MockVocabularyRepository) GetVocabulary(ctx context.Context) ([]entity.VocabularyTerm, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.terms, nil
+}
+
+func (m *MockVocabularyRepository) SaveVocabularyTerm(ctx context.Context, term entity.VocabularyTerm) error {
+	m.terms = append(m.terms, term)
+	return nil
+}
+
+// scoredTagExtractor returns fixed scored tags
+type scoredTagExtractor struct {
+	scoredTags []entity.ScoredTag
+}
+
+func (s *scoredTagExtractor) ExtractTags(doc entity.Document) []string {
+	return topTags(s.scoredTags, 10)
+}
+
+func (s *scoredTagExtractor) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	return s.scoredTags
+}
+
+func TestTaxonomyExtractorService_ExtractScoredTags(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "golang", Score: 4},
+		{Tag: "frontend", Score: 3},
+		{Tag: "js", Score: 2},
+		{Tag: "Go", Score: 1},
+		{Tag: "javascript", Score: 1},
+	}}
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{
+		{Tag: "go", Aliases: []string{"Golang", "go-lang"}},
+		{Tag: "javascript", Aliases: []string{"js", "ecmascript"}},
+	}}
+
+	tests := []struct {
+		name     string
+		enforce  bool
+		expected []entity.ScoredTag
+	}{
+		{
+			name: "Free tags kept",
+			expected: []entity.ScoredTag{
+				{Tag: "go", Score: 5},
+				{Tag: "frontend", Score: 3},
+				{Tag: "javascript", Score: 3},
+			},
+		},
+		{
+			name:    "Vocabulary enforced",
+			enforce: true,
+			expected: []entity.ScoredTag{
+				{Tag: "go", Score: 5},
+				{Tag: "javascript", Score: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor := NewTaxonomyExtractorService(inner, vocabulary, config.Taxonomy{Enforce: tt.enforce})
+			if err := extractor.Refresh(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if scoredTags := extractor.ExtractScoredTags(entity.Document{}); !reflect.DeepEqual(scoredTags, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, scoredTags)
+			}
+		})
+	}
+}
+
+func TestTaxonomyExtractorService_AliasDoesNotOverrideCanonical(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "java", Score: 1}}}
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{
+		{Tag: "javascript", Aliases: []string{"java"}},
+		{Tag: "java"},
+	}}
+
+	extractor := NewTaxonomyExtractorService(inner, vocabulary, config.Taxonomy{})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if tags := extractor.ExtractTags(entity.Document{}); !reflect.DeepEqual(tags, []string{"java"}) {
+		t.Errorf("Expected [java], got %v", tags)
+	}
+}
+
+func TestTaxonomyExtractorService_ProtectedTags(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{
+		{Tag: "c++", Score: 3},
+		{Tag: "c", Score: 2},
+		{Tag: "go 1.24", Score: 1},
+	}}
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{
+		{Tag: "C++", Aliases: []string{"cpp"}},
+		{Tag: "C"},
+		{Tag: "Go 1.24"},
+	}}
+
+	extractor := NewTaxonomyExtractorService(inner, vocabulary, config.Taxonomy{Enforce: true})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// "c++" must not collapse into "c"
+	expected := []string{"C++", "C", "Go 1.24"}
+	if tags := extractor.ExtractTags(entity.Document{}); !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+}
+
+func TestTaxonomyExtractorService_EnforcedEmptyVocabulary(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "golang", Score: 2}, {Tag: "generics", Score: 1}}}
+	vocabulary := &MockVocabularyRepository{}
+
+	extractor := NewTaxonomyExtractorService(inner, vocabulary, config.Taxonomy{Enforce: true})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// nothing to enforce yet, so the free tags are kept
+	expected := []string{"golang", "generics"}
+	if tags := extractor.ExtractTags(entity.Document{}); !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+
+	vocabulary.terms = []entity.VocabularyTerm{{Tag: "go", Aliases: []string{"golang"}}}
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tags := extractor.ExtractTags(entity.Document{}); !reflect.DeepEqual(tags, []string{"go"}) {
+		t.Errorf("Expected [go], got %v", tags)
+	}
+}
+
+func TestTaxonomyExtractorService_RefreshError(t *testing.T) {
+	inner := &scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "golang", Score: 1}}}
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{{Tag: "go", Aliases: []string{"golang"}}}}
+
+	extractor := NewTaxonomyExtractorService(inner, vocabulary, config.Taxonomy{})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// a failed refresh keeps the previous snapshot
+	vocabulary.err = errors.New("connection lost")
+	if err := extractor.Refresh(context.Background()); err == nil {
+		t.Fatal("Expected refresh error, got nil")
+	}
+
+	if tags := extractor.ExtractTags(entity.Document{}); !reflect.DeepEqual(tags, []string{"go"}) {
+		t.Errorf("Expected [go], got %v", tags)
+	}
+}
+
+func TestTaxonomyExtractorService_WithExtractor(t *testing.T) {
+	vocabulary := &MockVocabularyRepository{terms: []entity.VocabularyTerm{{Tag: "go", Aliases: []string{"golang"}}}}
+	extractor := NewTaxonomyExtractorService(NewTagExtractorService(), vocabulary, config.Taxonomy{})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tags := extractor.ExtractTags(entity.Document{
+		Title: "Golang generics",
+		Body:  "Generics arrived in golang after years of debate about the design.",
+	})
+	if len(tags) == 0 || tags[0] != "go" {
+		t.Errorf("Expected 'go' to rank first, got %v", tags)
+	}
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+const (
+	textRankDamping       = 0.85
+	textRankTolerance     = 1e-4
+	textRankMaxIterations = 100
+)
+
+// TextRankExtractorService ranks words with weighted PageRank over their
+// co-occurrence graph and merges adjacent top-ranked words into phrases. The
+// random jump favors words by the highest field and position weight they
+// occur with, so title words rank higher than the same words in the body.
+type TextRankExtractorService struct {
+	window   int
+	analyzer analyzer
+	limits   TagLimits
+}
+
+func NewTextRankExtractorService(cfg config.Extractor) *TextRankExtractorService {
+	if cfg.TextRankWindow < 2 {
+		cfg.TextRankWindow = config.DefaultExtractor().TextRankWindow
+	}
+	return &TextRankExtractorService{
+		window:   cfg.TextRankWindow,
+		analyzer: newAnalyzer(cfg),
+		limits:   NewTagLimits(cfg),
+	}
+}
+
+func (t *TextRankExtractorService) ExtractTags(doc entity.Document) []string {
+	return t.limits.Tags(t.ExtractScoredTags(doc))
+}
+
+func (t *TextRankExtractorService) tagLimits() TagLimits {
+	return t.limits
+}
+
+func (t *TextRankExtractorService) tagAnalyzer() analyzer {
+	return t.analyzer
+}
+
+// ExtractScoredTags returns every keyphrase ranked by the sum of the TextRank
+// scores of its words.
+func (t *TextRankExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	// term keys of every fragment; stop words are kept as empty keys so they
+	// still separate phrases
+	fragments := [][]utils.Token{}
+	keys := [][]string{}
+	words := []string{}
+	bias := make(map[string]float64)
+	for _, f := range t.analyzer.weights.fields(doc) {
+		pos := 0
+		for _, fragment := range t.analyzer.fragments(language, f.text) {
+			fragmentKeys := make([]string, len(fragment))
+			for j, word := range fragment {
+				if t.analyzer.isStopWord(stopWords, doc.Tenant, language, word) {
+					continue
+				}
+				key := t.analyzer.key(language, word)
+				fragmentKeys[j] = key
+				words = append(words, key)
+				bias[key] = max(bias[key], t.analyzer.weights.at(f, pos+j))
+			}
+			fragments = append(fragments, fragment)
+			keys = append(keys, fragmentKeys)
+			pos += len(fragment)
+		}
+	}
+	if len(words) == 0 {
+		return []entity.ScoredTag{}
+	}
+
+	// filtered token sequence used to build the co-occurrence graph
+	nodes, edges := t.cooccurrenceGraph(words)
+	nodeBias := make([]float64, len(nodes))
+	for i, word := range nodes {
+		nodeBias[i] = bias[word]
+	}
+	ranks := pageRank(edges, nodeBias)
+	scores := make(map[string]float64, len(nodes))
+	for i, word := range nodes {
+		scores[word] = ranks[i]
+	}
+	keywords := topKeywords(scores, (len(scores)+2)/3)
+
+	// merge runs of adjacent keywords into phrases
+	type candidate struct {
+		score float64
+		first int
+		forms surfaceForms
+	}
+	candidates := []*candidate{}
+	index := make(map[string]*candidate)
+	pos := 0
+	for i, fragment := range fragments {
+		start := 0
+		for j := 0; j <= len(fragment); j++ {
+			if j < len(fragment) && keywords[keys[i][j]] {
+				continue
+			}
+			if j > start {
+				key := strings.Join(keys[i][start:j], " ")
+				c, ok := index[key]
+				if !ok {
+					c = &candidate{first: pos + start}
+					for _, word := range keys[i][start:j] {
+						c.score += scores[word]
+					}
+					index[key] = c
+					candidates = append(candidates, c)
+				}
+				c.forms.add(phraseText(fragment[start:j]))
+			}
+			start = j + 1
+		}
+		pos += len(fragment)
+	}
+
+	ranked := make([]rankedTerm, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, rankedTerm{term: c.forms.best(), score: c.score, first: c.first, frequency: c.forms.total()})
+	}
+
+	return rankTerms(ranked)
+}
+
+// ExplainTags finds the occurrences of scoredTags in doc. Their scores are
+// TextRank scores, which the frequency breakdown only approximates.
+func (t *TextRankExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	return explainTags(doc, t.analyzer, scoredTags, nil)
+}
+
+// cooccurrenceGraph links every pair of words that appear within the window
+// and weights each edge by the number of co-occurrences. It returns the
+// distinct words in order of first occurrence and the edges of each, indexed
+// alike.
+func (t *TextRankExtractorService) cooccurrenceGraph(words []string) ([]string, [][]graphEdge) {
+	nodes := []string{}
+	index := make(map[string]int)
+	ids := make([]int, len(words))
+	for i, word := range words {
+		id, ok := index[word]
+		if !ok {
+			id = len(nodes)
+			index[word] = id
+			nodes = append(nodes, word)
+		}
+		ids[i] = id
+	}
+
+	counts := make([]map[int]float64, len(nodes))
+	for i := range counts {
+		counts[i] = make(map[int]float64)
+	}
+	for i := range ids {
+		for j := i + 1; j < i+t.window && j < len(ids); j++ {
+			if ids[i] == ids[j] {
+				continue
+			}
+			counts[ids[i]][ids[j]]++
+			counts[ids[j]][ids[i]]++
+		}
+	}
+
+	edges := make([][]graphEdge, len(nodes))
+	for node, neighbors := range counts {
+		edges[node] = make([]graphEdge, 0, len(neighbors))
+		for neighbor, weight := range neighbors {
+			edges[node] = append(edges[node], graphEdge{to: neighbor, weight: weight})
+		}
+		slices.SortFunc(edges[node], func(a, b graphEdge) int { return a.to - b.to })
+	}
+	return nodes, edges
+}
+
+// graphEdge links a node of an index-based graph to the node at index to.
+type graphEdge struct {
+	to     int
+	weight float64
+}
+
+// pageRank runs weighted PageRank on an undirected graph until no score
+// changes by more than the tolerance. edges holds the edges of every node,
+// sorted by the index they lead to. The random jump lands on each node in
+// proportion to its bias; a nil bias, or one without any positive weight,
+// counts every node as 1. Nodes and edges
+// are visited in index order, so the floating-point sums, and thus the
+// scores, are the same on every run.
+func pageRank(edges [][]graphEdge, bias []float64) []float64 {
+	n := len(edges)
+	outWeight := make([]float64, n)
+	scores := make([]float64, n)
+	jump := make([]float64, n)
+	totalBias := 0.0
+	for node, nodeEdges := range edges {
+		scores[node] = 1
+		for _, e := range nodeEdges {
+			outWeight[node] += e.weight
+		}
+		jump[node] = 1
+		if bias != nil {
+			jump[node] = bias[node]
+		}
+		totalBias += jump[node]
+	}
+	// scale the jump so it averages 1, as in unbiased TextRank
+	for node := range jump {
+		if totalBias > 0 {
+			jump[node] *= float64(n) / totalBias
+		} else {
+			jump[node] = 1
+		}
+	}
+
+	next := make([]float64, n)
+	for iter := 0; iter < textRankMaxIterations; iter++ {
+		delta := 0.0
+		for node, nodeEdges := range edges {
+			sum := 0.0
+			for _, e := range nodeEdges {
+				sum += e.weight / outWeight[e.to] * scores[e.to]
+			}
+			next[node] = (1-textRankDamping)*jump[node] + textRankDamping*sum
+			delta = math.Max(delta, math.Abs(next[node]-scores[node]))
+		}
+		scores, next = next, scores
+		if delta < textRankTolerance {
+			break
+		}
+	}
+	return scores
+}
+
+// topKeywords returns the n highest scoring words, breaking ties
+// alphabetically so the selection is deterministic.
+func topKeywords(scores map[string]float64, n int) map[string]bool {
+	ranked := make([]rankedTerm, 0, len(scores))
+	for word, score := range scores {
+		ranked = append(ranked, rankedTerm{term: word, score: score})
+	}
+
+	keywords := make(map[string]bool, n)
+	for i, st := range rankTerms(ranked) {
+		if i >= n {
+			break
+		}
+		keywords[st.Tag] = true
+	}
+	return keywords
+}
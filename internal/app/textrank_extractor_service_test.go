@@ -0,0 +1,190 @@
+package app
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestTextRankExtractorService_ExtractScoredTags(t *testing.T) {
+	// flat field weights, so only the co-occurrence graph decides
+	extractor := NewTextRankExtractorService(config.Extractor{TitleWeight: 1})
+
+	title := "Compatibility of systems of linear constraints"
+	body := "Criteria of compatibility of a system of linear Diophantine equations, strict inequations, " +
+		"and nonstrict inequations are considered. Upper bounds for components of a minimal set of " +
+		"solutions and algorithms of construction of minimal generating sets of solutions for all types " +
+		"of systems are given."
+
+	scoredTags := extractor.ExtractScoredTags(entity.Document{Title: title, Body: body})
+	if len(scoredTags) == 0 {
+		t.Fatal("Expected scored tags, got none")
+	}
+
+	found := make(map[string]bool)
+	for i, st := range scoredTags {
+		if found[st.Tag] {
+			t.Errorf("Duplicate phrase found: %s", st.Tag)
+		}
+		found[st.Tag] = true
+		if i > 0 && st.Score > scoredTags[i-1].Score {
+			t.Errorf("Scores are not sorted: %v", scoredTags)
+		}
+	}
+
+	// well connected words are kept and adjacent keywords merge into a phrase
+	for _, phrase := range []string{"upper bounds", "systems", "linear", "solutions"} {
+		if !found[phrase] {
+			t.Errorf("Expected phrase '%s' in %v", phrase, scoredTags)
+		}
+	}
+	if found["upper"] || found["bounds"] {
+		t.Errorf("Expected 'upper bounds' to be merged, got %v", scoredTags)
+	}
+}
+
+func TestTextRankExtractorService_Window(t *testing.T) {
+	body := "alpha beta gamma delta"
+
+	// with the default window only neighbours are linked, so the inner words rank highest
+	tags := NewTextRankExtractorService(config.Extractor{TextRankWindow: 2}).ExtractScoredTags(entity.Document{Body: body})
+	if len(tags) != 1 || tags[0].Tag != "beta gamma" {
+		t.Errorf("Expected ['beta gamma'], got %v", tags)
+	}
+
+	// a window spanning every word makes the graph complete and all words equal
+	nodes, edges := NewTextRankExtractorService(config.Extractor{TextRankWindow: 4}).cooccurrenceGraph([]string{"alpha", "beta", "gamma", "delta"})
+	for i, word := range nodes {
+		if len(edges[i]) != 3 {
+			t.Errorf("Expected %s to have 3 neighbours, got %v", word, edges[i])
+		}
+	}
+}
+
+func TestTextRankExtractorService_Deterministic(t *testing.T) {
+	extractor := NewTextRankExtractorService(config.Extractor{TextRankWindow: 3})
+
+	title := "Graph databases"
+	body := "Graph databases store nodes and edges. Query engines traverse edges between nodes quickly."
+
+	first := extractor.ExtractTags(entity.Document{Title: title, Body: body})
+	for run := 0; run < 20; run++ {
+		if tags := extractor.ExtractTags(entity.Document{Title: title, Body: body}); !reflect.DeepEqual(tags, first) {
+			t.Fatalf("Run %d: expected %v, got %v", run, first, tags)
+		}
+	}
+}
+
+func TestTextRankExtractorService_RepeatableScores(t *testing.T) {
+	extractor := NewTextRankExtractorService(config.Extractor{TextRankWindow: 4})
+	doc := entity.Document{
+		Title: "Distributed consensus in practice",
+		Body: "Raft elects a leader that replicates a log to followers. Followers acknowledge entries, " +
+			"and the leader commits an entry once a majority has stored it. Paxos reaches the same consensus " +
+			"with proposers and acceptors, while log compaction keeps replicated state small. " +
+			"Leader election, log replication and membership changes make consensus protocols subtle.",
+	}
+
+	// scores are compared exactly: any change in summation order shows
+	first := extractor.ExtractScoredTags(doc)
+	for run := 0; run < 100; run++ {
+		if scored := extractor.ExtractScoredTags(doc); !reflect.DeepEqual(scored, first) {
+			t.Fatalf("Run %d: expected %v, got %v", run, first, scored)
+		}
+	}
+}
+
+func TestTextRankExtractorService_EmptyContent(t *testing.T) {
+	extractor := NewTextRankExtractorService(config.Extractor{})
+
+	if tags := extractor.ExtractTags(entity.Document{Body: "the and of"}); len(tags) != 0 {
+		t.Errorf("Expected no tags, got %v", tags)
+	}
+	if extractor.window != config.DefaultExtractor().TextRankWindow {
+		t.Errorf("Expected default window %d, got %d", config.DefaultExtractor().TextRankWindow, extractor.window)
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	// a star graph: the hub collects score from every leaf
+	const hub, a, b, c = 0, 1, 2, 3
+	graph := [][]graphEdge{
+		hub: {{to: a, weight: 1}, {to: b, weight: 1}, {to: c, weight: 1}},
+		a:   {{to: hub, weight: 1}},
+		b:   {{to: hub, weight: 1}},
+		c:   {{to: hub, weight: 1}},
+	}
+
+	scores := pageRank(graph, nil)
+	if scores[hub] <= scores[a] {
+		t.Errorf("Expected hub to outrank leaves, got %v", scores)
+	}
+	if math.Abs(scores[a]-scores[b]) > 1e-9 || math.Abs(scores[b]-scores[c]) > 1e-9 {
+		t.Errorf("Expected leaves to share a score, got %v", scores)
+	}
+
+	total := 0.0
+	for _, score := range scores {
+		total += score
+	}
+	if math.Abs(total-float64(len(graph))) > 1e-3 {
+		t.Errorf("Expected scores to sum to %d, got %f", len(graph), total)
+	}
+}
+
+func TestPageRank_ZeroBias(t *testing.T) {
+	graph := [][]graphEdge{
+		{{to: 1, weight: 1}},
+		{{to: 0, weight: 1}, {to: 2, weight: 1}},
+		{{to: 1, weight: 1}},
+	}
+
+	// a zero bias jumps uniformly, like no bias
+	scores := pageRank(graph, []float64{0, 0, 0})
+	expected := pageRank(graph, nil)
+	for node, score := range scores {
+		if math.IsNaN(score) || math.Abs(score-expected[node]) > 1e-9 {
+			t.Errorf("Expected %v, got %v", expected, scores)
+			break
+		}
+	}
+}
+
+func TestTextRankExtractorService_Stemming(t *testing.T) {
+	title := "Databases"
+	body := "A database stores records. Databases index records, and every database replicates records."
+
+	tags := NewTextRankExtractorService(config.Extractor{Stemming: true}).ExtractTags(entity.Document{Title: title, Body: body})
+
+	found := make(map[string]bool)
+	for _, tag := range tags {
+		found[tag] = true
+	}
+	if found["database"] {
+		t.Errorf("Expected 'database' to be merged into 'databases', got %v", tags)
+	}
+	if !found["databases"] || !found["records"] {
+		t.Errorf("Expected 'databases' and 'records', got %v", tags)
+	}
+}
+
+func TestTextRankExtractorService_ProtectedTokens(t *testing.T) {
+	body := "The C++ compiler builds C++ modules. Every C++ module links the runtime."
+
+	tags := NewTextRankExtractorService(config.Extractor{ProtectedTokens: true}).ExtractTags(entity.Document{Body: body})
+
+	found := false
+	for _, tag := range tags {
+		if tag == "c" {
+			t.Errorf("Expected 'c++' to stay whole, got %v", tags)
+		}
+		found = found || strings.HasPrefix(tag, "c++")
+	}
+	if !found {
+		t.Errorf("Expected a tag starting with 'c++', got %v", tags)
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// TFIDFExtractorService scores terms by term frequency weighted with the
+// inverse document frequency of the stored corpus. Document frequencies are
+// read from an in-memory snapshot that is swapped atomically on refresh.
+type TFIDFExtractorService struct {
+	source   port.DocumentFrequencyRepository
+	snapshot atomic.Pointer[entity.DocumentFrequencies]
+	analyzer analyzer
+	limits   TagLimits
+}
+
+func NewTFIDFExtractorService(source port.DocumentFrequencyRepository, cfg config.Extractor) *TFIDFExtractorService {
+	t := &TFIDFExtractorService{
+		source:   source,
+		analyzer: newAnalyzer(cfg),
+		limits:   NewTagLimits(cfg),
+	}
+	t.snapshot.Store(&entity.DocumentFrequencies{Terms: map[string]int{}})
+	return t
+}
+
+// Refresh loads a new document-frequency snapshot from the repository.
+func (t *TFIDFExtractorService) Refresh(ctx context.Context) error {
+	df, err := t.source.GetDocumentFrequencies(ctx)
+	if err != nil {
+		return err
+	}
+	if df.Terms == nil {
+		df.Terms = map[string]int{}
+	}
+	t.snapshot.Store(df)
+	return nil
+}
+
+// StartRefresh refreshes the snapshot immediately and then on every interval
+// until ctx is cancelled.
+func (t *TFIDFExtractorService) StartRefresh(ctx context.Context, interval time.Duration) {
+	if err := t.Refresh(ctx); err != nil {
+		log.Printf("failed to refresh document frequencies: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.Refresh(ctx); err != nil {
+				log.Printf("failed to refresh document frequencies: %v", err)
+			}
+		}
+	}
+}
+
+func (t *TFIDFExtractorService) ExtractTags(doc entity.Document) []string {
+	return t.limits.Tags(t.ExtractScoredTags(doc))
+}
+
+func (t *TFIDFExtractorService) tagLimits() TagLimits {
+	return t.limits
+}
+
+func (t *TFIDFExtractorService) tagAnalyzer() analyzer {
+	return t.analyzer
+}
+
+// ExtractScoredTags returns every candidate tag ranked by tf-idf, where the
+// term frequency is weighted by field and position.
+func (t *TFIDFExtractorService) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	df := t.snapshot.Load()
+	terms := countTerms(doc, t.analyzer)
+
+	ranked := make([]rankedTerm, 0, len(terms))
+	for _, tc := range terms {
+		// document frequencies are stored per surface form; a stemmed term
+		// occurs in at least as many documents as its most common form
+		documentFrequency := 0
+		for _, form := range tc.forms.forms {
+			documentFrequency = max(documentFrequency, df.Terms[form])
+		}
+
+		ranked = append(ranked, rankedTerm{
+			term:      tc.term,
+			score:     tc.weight * idf(df.TotalDocuments, documentFrequency),
+			first:     tc.first,
+			frequency: tc.count,
+		})
+	}
+
+	return rankTerms(ranked)
+}
+
+// ExplainTags explains scoredTags by their field-weighted frequency in doc and
+// the inverse document frequency of the forms they occur in.
+func (t *TFIDFExtractorService) ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation {
+	df := t.snapshot.Load()
+	return explainTags(doc, t.analyzer, scoredTags, func(forms []string) float64 {
+		documentFrequency := 0
+		for _, form := range forms {
+			documentFrequency = max(documentFrequency, df.Terms[form])
+		}
+		return idf(df.TotalDocuments, documentFrequency)
+	})
+}
+
+// idf is the smoothed inverse document frequency, which stays positive for
+// terms that occur in every document and finite for unseen terms.
+func idf(totalDocuments, documentFrequency int) float64 {
+	return math.Log(float64(totalDocuments+1)/float64(documentFrequency+1)) + 1
+}
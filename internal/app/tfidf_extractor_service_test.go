@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// MockDocumentFrequencyRepository is a mock implementation of DocumentFrequencyRepository
+type MockDocumentFrequencyRepository struct {
+	df        *entity.DocumentFrequencies
+	err       error
+	callCount int
+}
+
+func (m *MockDocumentFrequencyRepository) GetDocumentFrequencies(ctx context.Context) (*entity.DocumentFrequencies, error) {
+	m.callCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.df, nil
+}
+
+func TestTFIDFExtractorService_ExtractScoredTags(t *testing.T) {
+	mockRepo := &MockDocumentFrequencyRepository{
+		df: &entity.DocumentFrequencies{
+			TotalDocuments: 100,
+			Terms: map[string]int{
+				"data":   90,
+				"system": 80,
+				"raft":   2,
+			},
+		},
+	}
+	extractor := NewTFIDFExtractorService(mockRepo, config.DefaultExtractor())
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	title := "Raft consensus"
+	body := "The data system replicates data through the system log. Raft keeps the data consistent."
+
+	scoredTags := extractor.ExtractScoredTags(entity.Document{Title: title, Body: body})
+	if len(scoredTags) == 0 {
+		t.Fatal("Expected scored tags, got none")
+	}
+
+	// rare terms outrank common ones even with fewer occurrences
+	if scoredTags[0].Tag != "raft" {
+		t.Errorf("Expected 'raft' to rank first, got %v", scoredTags)
+	}
+
+	rank := make(map[string]int)
+	for i, st := range scoredTags {
+		rank[st.Tag] = i
+	}
+	if rank["consensus"] > rank["data"] {
+		t.Errorf("Expected unseen term 'consensus' to outrank 'data', got %v", scoredTags)
+	}
+
+	for i := 1; i < len(scoredTags); i++ {
+		if scoredTags[i].Score > scoredTags[i-1].Score {
+			t.Errorf("Scores are not sorted: %v", scoredTags)
+		}
+	}
+}
+
+func TestTFIDFExtractorService_EmptySnapshot(t *testing.T) {
+	extractor := NewTFIDFExtractorService(&MockDocumentFrequencyRepository{}, config.DefaultExtractor())
+
+	// without a snapshot every term has the same idf, so ranking follows frequency
+	tags := extractor.ExtractTags(entity.Document{Title: "Go Go", Body: "Go is fast and go is simple"})
+	if len(tags) == 0 || tags[0] != "go" {
+		t.Errorf("Expected 'go' to rank first, got %v", tags)
+	}
+}
+
+func TestTFIDFExtractorService_RefreshError(t *testing.T) {
+	mockRepo := &MockDocumentFrequencyRepository{
+		df: &entity.DocumentFrequencies{TotalDocuments: 10, Terms: map[string]int{"go": 10}},
+	}
+	extractor := NewTFIDFExtractorService(mockRepo, config.DefaultExtractor())
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// a failed refresh keeps the previous snapshot
+	mockRepo.err = errors.New("database error")
+	if err := extractor.Refresh(context.Background()); err == nil {
+		t.Error("Expected error but got none")
+	}
+	if got := extractor.snapshot.Load().Terms["go"]; got != 10 {
+		t.Errorf("Expected previous snapshot to be kept, got df %d", got)
+	}
+}
+
+func TestTFIDFExtractorService_StartRefresh(t *testing.T) {
+	mockRepo := &MockDocumentFrequencyRepository{
+		df: &entity.DocumentFrequencies{TotalDocuments: 1, Terms: map[string]int{"go": 1}},
+	}
+	extractor := NewTFIDFExtractorService(mockRepo, config.DefaultExtractor())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the first refresh happens before the loop observes the cancelled context
+	extractor.StartRefresh(ctx, time.Hour)
+	if mockRepo.callCount != 1 {
+		t.Errorf("Expected 1 refresh, got %d", mockRepo.callCount)
+	}
+}
+
+func TestTFIDFExtractorService_Stemming(t *testing.T) {
+	mockRepo := &MockDocumentFrequencyRepository{
+		df: &entity.DocumentFrequencies{
+			TotalDocuments: 100,
+			Terms:          map[string]int{"servers": 90, "server": 5, "cluster": 50},
+		},
+	}
+	extractor := NewTFIDFExtractorService(mockRepo, config.Extractor{Stemming: true})
+	if err := extractor.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scoredTags := extractor.ExtractScoredTags(entity.Document{Title: "Cluster servers", Body: "The cluster has a server and servers."})
+
+	// "server" and "servers" merge and take the document frequency of the more common form
+	scores := make(map[string]float64)
+	for _, st := range scoredTags {
+		scores[st.Tag] = st.Score
+	}
+	if _, ok := scores["server"]; ok || len(scores) != 2 {
+		t.Fatalf("Expected [servers cluster], got %v", scoredTags)
+	}
+	// the title occurrence counts twice
+	if expected := 4 * idf(100, 90); scores["servers"] != expected {
+		t.Errorf("Expected score %f for 'servers', got %f", expected, scores["servers"])
+	}
+}
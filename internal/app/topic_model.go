@@ -0,0 +1,327 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
+)
+
+// Defaults of the topic model settings.
+const (
+	defaultTopicCount      = 20
+	defaultTopicIterations = 200
+	defaultTopicAlpha      = 0.1
+	defaultTopicBeta       = 0.01
+	defaultTopicWords      = 10
+)
+
+// ErrNoTopicData is returned when no stored article has words to model.
+var ErrNoTopicData = errors.New("no articles with words to model topics on")
+
+// TopicModel is a Latent Dirichlet Allocation model of the stored articles:
+// the most probable words of each topic and the topic mixture of each
+// article.
+type TopicModel struct {
+	TrainedAt  time.Time
+	Documents  int
+	Vocabulary int
+	Topics     []entity.Topic
+	// Mixtures maps the ID of every article to its topics, largest weight
+	// first; articles without modeled words have none
+	Mixtures map[string][]entity.TopicWeight
+}
+
+// TopicTrainer collects the words of articles and fits a topic model to
+// them with collapsed Gibbs sampling.
+type TopicTrainer struct {
+	analyzer analyzer
+	cfg      config.Topics
+	ids      []string
+	docs     [][]int32
+	// index maps analyzed words to their IDs; forms holds the spellings of
+	// each ID and df the number of articles it occurs in
+	index map[string]int32
+	forms []surfaceForms
+	df    []int
+}
+
+// NewTopicTrainer returns a trainer analyzing words like the extractors
+// configured by extractor.
+func NewTopicTrainer(cfg config.Topics, extractor config.Extractor) *TopicTrainer {
+	if cfg.Count <= 0 {
+		cfg.Count = defaultTopicCount
+	}
+	if cfg.Iterations <= 0 {
+		cfg.Iterations = defaultTopicIterations
+	}
+	if cfg.Alpha <= 0 {
+		cfg.Alpha = defaultTopicAlpha
+	}
+	if cfg.Beta <= 0 {
+		cfg.Beta = defaultTopicBeta
+	}
+	if cfg.Words <= 0 {
+		cfg.Words = defaultTopicWords
+	}
+	return &TopicTrainer{
+		analyzer: newAnalyzer(extractor),
+		cfg:      cfg,
+		index:    make(map[string]int32),
+	}
+}
+
+// Add collects the words of article in the order they occur, leaving out
+// stop words.
+func (t *TopicTrainer) Add(article *entity.Article) {
+	doc := articleDocument(article)
+	language := documentLanguage(doc)
+	stopWords := utils.CurrentStopWords()
+
+	var words []int32
+	seen := make(map[int32]bool)
+	for _, f := range t.analyzer.weights.fields(doc) {
+		for _, token := range t.analyzer.tokens(language, f.text) {
+			if t.analyzer.isStopWord(stopWords, doc.Tenant, language, token) {
+				continue
+			}
+			key := t.analyzer.key(language, token)
+			id, ok := t.index[key]
+			if !ok {
+				id = int32(len(t.forms))
+				t.index[key] = id
+				t.forms = append(t.forms, surfaceForms{})
+				t.df = append(t.df, 0)
+			}
+			t.forms[id].add(token.Text)
+			if !seen[id] {
+				seen[id] = true
+				t.df[id]++
+			}
+			words = append(words, id)
+		}
+	}
+	t.ids = append(t.ids, article.ID)
+	t.docs = append(t.docs, words)
+}
+
+// Train fits the topic model to the articles added so far. Words occurring
+// in fewer than the configured number of articles are left out first.
+func (t *TopicTrainer) Train(ctx context.Context) (*TopicModel, error) {
+	docs, words := t.prune()
+	tokens := 0
+	for _, doc := range docs {
+		tokens += len(doc)
+	}
+	if tokens == 0 {
+		return nil, ErrNoTopicData
+	}
+
+	lda := newLDA(docs, len(words), t.cfg)
+	for range t.cfg.Iterations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		lda.sweep()
+	}
+
+	model := &TopicModel{
+		TrainedAt:  time.Now(),
+		Documents:  len(t.docs),
+		Vocabulary: len(words),
+		Topics:     make([]entity.Topic, lda.k),
+		Mixtures:   make(map[string][]entity.TopicWeight, len(t.ids)),
+	}
+	for k := range model.Topics {
+		model.Topics[k] = entity.Topic{
+			ID:     k,
+			Weight: float64(lda.topicTotals[k]) / float64(tokens),
+			Words:  t.topWords(lda, k, words),
+		}
+	}
+	for d, id := range t.ids {
+		mixture := lda.mixture(d, t.cfg.MinWeight)
+		for _, w := range mixture {
+			model.Topics[w.Topic].Articles++
+		}
+		model.Mixtures[id] = mixture
+	}
+	return model, nil
+}
+
+// prune returns the documents with the words occurring in too few articles
+// left out, renumbering the remaining words, and the old IDs of the new
+// ones.
+func (t *TopicTrainer) prune() ([][]int32, []int32) {
+	kept := make([]int32, len(t.forms))
+	var words []int32
+	for id, df := range t.df {
+		kept[id] = -1
+		if df >= t.cfg.MinDocuments {
+			kept[id] = int32(len(words))
+			words = append(words, int32(id))
+		}
+	}
+
+	docs := make([][]int32, len(t.docs))
+	for d, doc := range t.docs {
+		for _, id := range doc {
+			if kept[id] >= 0 {
+				docs[d] = append(docs[d], kept[id])
+			}
+		}
+	}
+	return docs, words
+}
+
+// topWords returns the most probable words of topic k in their most frequent
+// spelling. words maps the word IDs of lda to those of the trainer.
+func (t *TopicTrainer) topWords(lda *lda, k int, words []int32) []entity.TopicWord {
+	ids := make([]int, len(words))
+	for w := range ids {
+		ids[w] = w
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return lda.topicWordCounts[ids[i]*lda.k+k] > lda.topicWordCounts[ids[j]*lda.k+k]
+	})
+
+	top := make([]entity.TopicWord, 0, min(t.cfg.Words, len(ids)))
+	for _, w := range ids[:cap(top)] {
+		top = append(top, entity.TopicWord{
+			Word:        t.forms[words[w]].best(),
+			Probability: lda.wordProbability(w, k),
+		})
+	}
+	return top
+}
+
+// lda holds the state of collapsed Gibbs sampling: the topic of every word
+// of every document and the counts of words per topic and of topics per
+// document. The count of word w in topic t is at w*k+t, that of topic t in
+// document d at d*k+t.
+type lda struct {
+	docs            [][]int32
+	topics          [][]int32
+	k               int
+	vocabulary      int
+	alpha, beta     float64
+	topicWordCounts []int32
+	docTopicCounts  []int32
+	topicTotals     []int32
+	rng             *rand.Rand
+	p               []float64
+}
+
+// newLDA assigns every word of docs a random topic.
+func newLDA(docs [][]int32, vocabulary int, cfg config.Topics) *lda {
+	seed := uint64(cfg.Seed)
+	l := &lda{
+		docs:            docs,
+		topics:          make([][]int32, len(docs)),
+		k:               cfg.Count,
+		vocabulary:      vocabulary,
+		alpha:           cfg.Alpha,
+		beta:            cfg.Beta,
+		topicWordCounts: make([]int32, vocabulary*cfg.Count),
+		docTopicCounts:  make([]int32, len(docs)*cfg.Count),
+		topicTotals:     make([]int32, cfg.Count),
+		rng:             rand.New(rand.NewPCG(seed, seed)),
+		p:               make([]float64, cfg.Count),
+	}
+	for d, doc := range docs {
+		l.topics[d] = make([]int32, len(doc))
+		for i, w := range doc {
+			topic := int32(l.rng.IntN(l.k))
+			l.topics[d][i] = topic
+			l.count(d, w, topic, 1)
+		}
+	}
+	return l
+}
+
+func (l *lda) count(d int, w, topic int32, n int32) {
+	l.topicWordCounts[int(w)*l.k+int(topic)] += n
+	l.docTopicCounts[d*l.k+int(topic)] += n
+	l.topicTotals[topic] += n
+}
+
+// sweep samples a new topic for every word from the distribution given the
+// topics of all other words.
+func (l *lda) sweep() {
+	vBeta := float64(l.vocabulary) * l.beta
+	for d, doc := range l.docs {
+		docCounts := l.docTopicCounts[d*l.k : (d+1)*l.k]
+		for i, w := range doc {
+			l.count(d, w, l.topics[d][i], -1)
+
+			wordCounts := l.topicWordCounts[int(w)*l.k : (int(w)+1)*l.k]
+			total := 0.0
+			for k := range l.p {
+				total += (float64(wordCounts[k]) + l.beta) / (float64(l.topicTotals[k]) + vBeta) *
+					(float64(docCounts[k]) + l.alpha)
+				l.p[k] = total
+			}
+			u := l.rng.Float64() * total
+			topic := sort.SearchFloat64s(l.p, u)
+			topic = min(topic, l.k-1)
+
+			l.topics[d][i] = int32(topic)
+			l.count(d, w, int32(topic), 1)
+		}
+	}
+}
+
+// wordProbability returns the probability of word w in topic k.
+func (l *lda) wordProbability(w, k int) float64 {
+	return (float64(l.topicWordCounts[w*l.k+k]) + l.beta) /
+		(float64(l.topicTotals[k]) + float64(l.vocabulary)*l.beta)
+}
+
+// mixture returns the topics of document d weighing at least minWeight,
+// largest weight first.
+func (l *lda) mixture(d int, minWeight float64) []entity.TopicWeight {
+	if len(l.docs[d]) == 0 {
+		return nil
+	}
+
+	var mixture []entity.TopicWeight
+	norm := float64(len(l.docs[d])) + float64(l.k)*l.alpha
+	for k := range l.k {
+		weight := (float64(l.docTopicCounts[d*l.k+k]) + l.alpha) / norm
+		if weight >= minWeight {
+			mixture = append(mixture, entity.TopicWeight{Topic: k, Weight: weight})
+		}
+	}
+	sort.SliceStable(mixture, func(i, j int) bool {
+		return mixture[i].Weight > mixture[j].Weight
+	})
+	return mixture
+}
+
+// TrainTopicModel fits a topic model to every stored article.
+func TrainTopicModel(ctx context.Context, repo port.TopicModelRepository, cfg config.Topics, extractor config.Extractor) (*TopicModel, error) {
+	trainer := NewTopicTrainer(cfg, extractor)
+	err := repo.ForEachArticle(ctx, func(article *entity.Article) error {
+		trainer.Add(article)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trainer.Train(ctx)
+}
+
+// Save stores the topics of the model, replacing those of the previous
+// model, and the topic mixture of every article.
+func (m *TopicModel) Save(ctx context.Context, repo port.TopicModelRepository) error {
+	if err := repo.SaveTopics(ctx, m.Topics); err != nil {
+		return err
+	}
+	return repo.SaveArticleTopics(ctx, m.Mixtures)
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+// MockTopicRepository is a mock implementation of TopicModelRepository and
+// TopicRepository
+type MockTopicRepository struct {
+	articles []*entity.Article
+	topics   []entity.Topic
+	mixtures map[string][]entity.TopicWeight
+	err      error
+}
+
+func (m *MockTopicRepository) ForEachArticle(ctx context.Context, fn func(article *entity.Article) error) error {
+	if m.err != nil {
+		return m.err
+	}
+	for _, article := range m.articles {
+		if err := fn(article); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockTopicRepository) SaveTopics(ctx context.Context, topics []entity.Topic) error {
+	m.topics = topics
+	return nil
+}
+
+func (m *MockTopicRepository) SaveArticleTopics(ctx context.Context, mixtures map[string][]entity.TopicWeight) error {
+	m.mixtures = mixtures
+	return nil
+}
+
+func (m *MockTopicRepository) GetTopics(ctx context.Context) ([]entity.Topic, error) {
+	return m.topics, m.err
+}
+
+func (m *MockTopicRepository) GetArticlesByTopic(ctx context.Context, topic int, limit int) ([]*entity.Article, error) {
+	return m.articles[:min(limit, len(m.articles))], m.err
+}
+
+// topicArticles are about programming or about cooking, with a few
+// articles about both.
+func topicArticles() []*entity.Article {
+	programming := []string{"compiler", "goroutine", "channel", "interface", "package", "runtime"}
+	cooking := []string{"recipe", "oven", "flour", "butter", "garlic", "simmer"}
+
+	var articles []*entity.Article
+	for i := range 12 {
+		words := programming
+		if i%2 == 1 {
+			words = cooking
+		}
+		body := ""
+		for j := range 30 {
+			body += words[(i+j)%len(words)] + " "
+		}
+		articles = append(articles, &entity.Article{ID: fmt.Sprintf("a%d", i), Title: words[i%len(words)], Body: body})
+	}
+	articles = append(articles, &entity.Article{ID: "empty", Title: "the", Body: "and of"})
+	return articles
+}
+
+func trainTopics(t *testing.T, articles []*entity.Article, seed int) *TopicModel {
+	t.Helper()
+	trainer := NewTopicTrainer(config.Topics{Count: 2, Iterations: 50, MinDocuments: 2, Words: 6, MinWeight: 0.2, Seed: seed}, config.DefaultExtractor())
+	for _, article := range articles {
+		trainer.Add(article)
+	}
+	model, err := trainer.Train(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return model
+}
+
+func TestTopicTrainer(t *testing.T) {
+	model := trainTopics(t, topicArticles(), 1)
+	if model.Documents != 13 || model.Vocabulary != 12 || len(model.Topics) != 2 {
+		t.Fatalf("Expected 2 topics of 12 words over 13 articles, got %+v", model)
+	}
+
+	// each topic holds the words of one theme
+	themes := make([]string, 2)
+	for k, topic := range model.Topics {
+		if len(topic.Words) != 6 {
+			t.Fatalf("Expected 6 words in topic %d, got %v", k, topic.Words)
+		}
+		for _, word := range topic.Words {
+			theme := "programming"
+			if word.Word == "recipe" || word.Word == "oven" || word.Word == "flour" ||
+				word.Word == "butter" || word.Word == "garlic" || word.Word == "simmer" {
+				theme = "cooking"
+			}
+			if themes[k] != "" && themes[k] != theme {
+				t.Errorf("Expected topic %d to hold one theme, got %v", k, topic.Words)
+			}
+			themes[k] = theme
+		}
+		if topic.Articles != 6 || topic.Weight < 0.4 || topic.Weight > 0.6 {
+			t.Errorf("Expected topic %d to cover half the archive, got %+v", k, topic)
+		}
+	}
+	if themes[0] == themes[1] {
+		t.Errorf("Expected the topics to differ, got %v", themes)
+	}
+
+	// articles of a theme share a dominant topic
+	for _, id := range []string{"a0", "a2", "a4"} {
+		mixture := model.Mixtures[id]
+		if len(mixture) != 1 || themes[mixture[0].Topic] != "programming" || mixture[0].Weight < 0.9 {
+			t.Errorf("Expected %s to be about programming, got %v", id, mixture)
+		}
+	}
+	if mixture := model.Mixtures["a1"]; len(mixture) != 1 || themes[mixture[0].Topic] != "cooking" {
+		t.Errorf("Expected a1 to be about cooking, got %v", mixture)
+	}
+	if mixture, ok := model.Mixtures["empty"]; !ok || mixture != nil {
+		t.Errorf("Expected no topics for an article of stop words, got %v", mixture)
+	}
+}
+
+func TestTopicTrainer_Mixture(t *testing.T) {
+	articles := append(topicArticles(), &entity.Article{
+		ID:   "both",
+		Body: "compiler goroutine channel runtime recipe oven flour garlic",
+	})
+	model := trainTopics(t, articles, 1)
+
+	mixture := model.Mixtures["both"]
+	if len(mixture) != 2 || mixture[0].Weight < mixture[1].Weight || mixture[0].Weight+mixture[1].Weight < 0.95 {
+		t.Errorf("Expected a mixture of both topics, largest first, got %v", mixture)
+	}
+}
+
+func TestTopicTrainer_Seed(t *testing.T) {
+	first := trainTopics(t, topicArticles(), 7)
+	second := trainTopics(t, topicArticles(), 7)
+	if !reflect.DeepEqual(first.Topics, second.Topics) || !reflect.DeepEqual(first.Mixtures, second.Mixtures) {
+		t.Error("Expected training with the same seed to be repeatable")
+	}
+}
+
+func TestTopicTrainer_NoData(t *testing.T) {
+	trainer := NewTopicTrainer(config.Topics{MinDocuments: 2}, config.DefaultExtractor())
+	trainer.Add(&entity.Article{ID: "a1", Body: "unique words only"})
+	if _, err := trainer.Train(context.Background()); !errors.Is(err, ErrNoTopicData) {
+		t.Errorf("Expected ErrNoTopicData, got %v", err)
+	}
+}
+
+func TestTopicTrainer_Canceled(t *testing.T) {
+	trainer := NewTopicTrainer(config.Topics{}, config.DefaultExtractor())
+	for _, article := range topicArticles() {
+		trainer.Add(article)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := trainer.Train(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTrainTopicModel(t *testing.T) {
+	repo := &MockTopicRepository{articles: topicArticles()}
+	model, err := TrainTopicModel(context.Background(), repo, config.Topics{Count: 2, Iterations: 20, MinDocuments: 2}, config.DefaultExtractor())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := model.Save(context.Background(), repo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(repo.topics) != 2 || len(repo.mixtures) != 13 {
+		t.Errorf("Expected 2 topics and 13 mixtures to be saved, got %d and %d", len(repo.topics), len(repo.mixtures))
+	}
+
+	repo.err = errors.New("connection refused")
+	if _, err := TrainTopicModel(context.Background(), repo, config.Topics{}, config.DefaultExtractor()); !errors.Is(err, repo.err) {
+		t.Errorf("Expected the repository error, got %v", err)
+	}
+}
+
+func TestArticleService_ListTopics(t *testing.T) {
+	service := NewArticleService(&MockArticleRepository{})
+	if _, err := service.ListTopics(context.Background(), 0); !errors.Is(err, ErrTopicsDisabled) {
+		t.Errorf("Expected ErrTopicsDisabled, got %v", err)
+	}
+	if _, err := service.GetArticlesByTopic(context.Background(), 0, 10); !errors.Is(err, ErrTopicsDisabled) {
+		t.Errorf("Expected ErrTopicsDisabled, got %v", err)
+	}
+
+	service.Topics = &MockTopicRepository{topics: []entity.Topic{
+		{ID: 0, Words: []entity.TopicWord{{Word: "go"}, {Word: "compiler"}, {Word: "runtime"}}},
+		{ID: 1, Words: []entity.TopicWord{{Word: "recipe"}}},
+	}}
+	topics, err := service.ListTopics(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(topics[0].Words) != 2 || len(topics[1].Words) != 1 {
+		t.Errorf("Expected at most 2 words per topic, got %v", topics)
+	}
+}
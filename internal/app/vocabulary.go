@@ -0,0 +1,76 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+)
+
+// ErrEmptyVocabulary is returned for a vocabulary file without terms.
+var ErrEmptyVocabulary = errors.New("vocabulary has no terms")
+
+// LoadVocabulary reads controlled vocabulary terms from the JSONL file at
+// path: one term per line with its canonical tag and aliases, as in
+// {"tag": "go", "aliases": ["golang"]}. Blank lines are skipped.
+func LoadVocabulary(path string) ([]entity.VocabularyTerm, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	terms, err := ReadVocabulary(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return terms, nil
+}
+
+// ReadVocabulary reads terms in the format of LoadVocabulary from r.
+func ReadVocabulary(r io.Reader) ([]entity.VocabularyTerm, error) {
+	terms := []entity.VocabularyTerm{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var term entity.VocabularyTerm
+		if err := json.Unmarshal(scanner.Bytes(), &term); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if term.Tag = strings.TrimSpace(term.Tag); term.Tag == "" {
+			return nil, fmt.Errorf("line %d: term has no tag", line)
+		}
+		if term.Aliases == nil {
+			term.Aliases = []string{}
+		}
+		terms = append(terms, term)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, ErrEmptyVocabulary
+	}
+	return terms, nil
+}
+
+// ImportVocabulary saves terms to repo, replacing the aliases of the terms it
+// already holds.
+func ImportVocabulary(ctx context.Context, repo port.VocabularyRepository, terms []entity.VocabularyTerm) error {
+	for _, term := range terms {
+		if err := repo.SaveVocabularyTerm(ctx, term); err != nil {
+			return fmt.Errorf("failed to save vocabulary term %s: %w", term.Tag, err)
+		}
+	}
+	return nil
+}
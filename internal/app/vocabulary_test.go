@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+)
+
+func TestReadVocabulary(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []entity.VocabularyTerm
+		err      string
+	}{
+		{
+			name:  "Terms with and without aliases",
+			input: "{\"tag\": \"go\", \"aliases\": [\"golang\"]}\n\n{\"tag\": \" kubernetes \"}\n",
+			expected: []entity.VocabularyTerm{
+				{Tag: "go", Aliases: []string{"golang"}},
+				{Tag: "kubernetes", Aliases: []string{}},
+			},
+		},
+		{
+			name:  "Missing tag",
+			input: "{\"tag\": \"go\"}\n{\"aliases\": [\"js\"]}\n",
+			err:   "line 2: term has no tag",
+		},
+		{
+			name:  "Invalid JSON",
+			input: "{\"tag\": \"go\"\n",
+			err:   "line 1:",
+		},
+		{
+			name:  "Empty",
+			input: "\n",
+			err:   ErrEmptyVocabulary.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms, err := ReadVocabulary(strings.NewReader(tt.input))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error '%s', got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(terms, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, terms)
+			}
+		})
+	}
+}
+
+func TestLoadVocabulary(t *testing.T) {
+	if _, err := LoadVocabulary(filepath.Join(t.TempDir(), "missing.jsonl")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+}
+
+func TestImportVocabulary(t *testing.T) {
+	repo := &MockVocabularyRepository{}
+	terms := []entity.VocabularyTerm{
+		{Tag: "go", Aliases: []string{"golang"}},
+		{Tag: "javascript", Aliases: []string{"js"}},
+	}
+
+	if err := ImportVocabulary(context.Background(), repo, terms); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(repo.terms, terms) {
+		t.Errorf("Expected %v saved, got %v", terms, repo.terms)
+	}
+
+	// imported terms are what the taxonomy maps onto
+	taxonomy := NewTaxonomyExtractorService(&scoredTagExtractor{scoredTags: []entity.ScoredTag{{Tag: "golang", Score: 1}}}, repo, config.Taxonomy{Enforce: true})
+	if err := taxonomy.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tags := taxonomy.ExtractTags(entity.Document{}); !reflect.DeepEqual(tags, []string{"go"}) {
+		t.Errorf("Expected [go], got %v", tags)
+	}
+}
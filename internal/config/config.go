@@ -1,8 +1,21 @@
 package config
 
+import "time"
+
 type Config struct {
-	Database Database
-	Server   Server
+	Database   Database
+	Server     Server
+	Extractor  Extractor
+	StopWords  StopWords
+	Taxonomy   Taxonomy
+	Policy     Policy
+	Entities   Entities
+	Gazetteer  Gazetteer
+	Ensemble   Ensemble
+	Classifier Classifier
+	Feedback   Feedback
+	Summarizer Summarizer
+	Topics     Topics
 }
 
 type Database struct {
@@ -13,3 +26,144 @@ type Database struct {
 type Server struct {
 	GRPCPort string
 }
+
+type Extractor struct {
+	// Algorithm is the default extractor; Available lists the extractors
+	// requests may select by name
+	Algorithm       string
+	Available       []string
+	RefreshInterval time.Duration
+	MaxPhraseWords  int
+	TextRankWindow  int
+	Stemming        bool
+	TitleWeight     float64
+	BodyWeight      float64
+	SummaryWeight   float64
+	HeadingWeight   float64
+	// PositionHalfLife is the body token position at which the early-position
+	// boost has halved. Zero disables position decay.
+	PositionHalfLife int
+	// ProtectedTokens keeps technical terms such as "C++", "COVID-19" and
+	// "node.js" intact as single tokens. ProtectedPatternsFile names a file
+	// of further regular expressions, one per line.
+	ProtectedTokens       bool
+	ProtectedPatternsFile string
+	// MaxTags is the number of tags kept for an article. MinFrequency and
+	// MinScore drop tags occurring fewer times or scoring below that
+	// fraction of the top score; zero keeps every tag.
+	MaxTags      int
+	MinFrequency int
+	MinScore     float64
+}
+
+// StopWords names stop-word files or directories layered over the embedded
+// lists. A zero ReloadInterval disables polling for changes.
+type StopWords struct {
+	Paths          []string
+	ReloadInterval time.Duration
+}
+
+// Taxonomy controls mapping extracted tags onto the controlled vocabulary.
+// With Enforce set only vocabulary tags are emitted; otherwise tags outside
+// the vocabulary are kept as free tags.
+type Taxonomy struct {
+	Enabled         bool
+	Enforce         bool
+	RefreshInterval time.Duration
+}
+
+// Policy names the JSON file of tag policies. An empty File accepts every tag.
+type Policy struct {
+	File string
+}
+
+// Entities controls detecting named entities such as "New York Times" and
+// "NASA". Boost multiplies the score of entity tags.
+type Entities struct {
+	Enabled bool
+	Boost   float64
+}
+
+// Gazetteer names gazetteer files or directories of known entities to link
+// tags to. Boost multiplies the score of linked tags. No paths disables
+// linking.
+type Gazetteer struct {
+	Paths []string
+	Boost float64
+}
+
+// Ensemble combines the extractors named in Weights into one. Method is
+// "rrf" for reciprocal rank fusion, whose RRFK damps the lead of the top
+// ranks, or "vote" for voting with normalized scores. No weights disables
+// the ensemble.
+type Ensemble struct {
+	Weights map[string]float64
+	Method  string
+	RRFK    float64
+}
+
+// Classifier names the category model written by the train command. An
+// empty ModelFile disables classification. Articles get at most
+// MaxCategories categories whose probability is at least MinProbability;
+// Alpha is the additive smoothing of word counts.
+type Classifier struct {
+	ModelFile      string
+	MinProbability float64
+	MaxCategories  int
+	Alpha          float64
+}
+
+// Summarizer sets the number of key sentences in the summary of each
+// article. Zero disables summaries.
+type Summarizer struct {
+	Sentences int
+}
+
+// Topics controls the topic model the topics command trains: Count topics
+// fit by Iterations sweeps of Gibbs sampling with the Dirichlet priors Alpha
+// on the topics of an article and Beta on the words of a topic. Words
+// occurring in fewer than MinDocuments articles are left out. Each topic
+// keeps its Words most probable words and each article the topics weighing
+// at least MinWeight. Seed makes training repeatable.
+type Topics struct {
+	Count        int
+	Iterations   int
+	Alpha        float64
+	Beta         float64
+	MinDocuments int
+	Words        int
+	MinWeight    float64
+	Seed         int
+}
+
+// Feedback controls learning from editor decisions on tags. Tags editors
+// accept or add are boosted and tags they reject penalized, per source, by
+// up to a factor of e^Strength; Smoothing is the number of decisions needed
+// for half of that.
+type Feedback struct {
+	Enabled         bool
+	Strength        float64
+	Smoothing       float64
+	RefreshInterval time.Duration
+}
+
+// DefaultExtractor returns the extractor configuration used when nothing is
+// overridden.
+func DefaultExtractor() Extractor {
+	return Extractor{
+		Algorithm:        "frequency",
+		Available:        []string{"frequency", "tfidf", "rake", "textrank"},
+		RefreshInterval:  5 * time.Minute,
+		MaxPhraseWords:   3,
+		TextRankWindow:   2,
+		Stemming:         false,
+		TitleWeight:      2,
+		BodyWeight:       1,
+		SummaryWeight:    1.5,
+		HeadingWeight:    1.5,
+		PositionHalfLife: 0,
+		MaxTags:          10,
+		MinFrequency:     0,
+		MinScore:         0,
+	}
+}
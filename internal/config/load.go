@@ -1,10 +1,16 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func LoadConfig() *Config {
+	extractor := DefaultExtractor()
+
 	return &Config{
 		Database: Database{
 			URI:        getEnv("MONGODB_URI", "mongodb://localhost:27017"),
@@ -13,6 +19,74 @@ func LoadConfig() *Config {
 		Server: Server{
 			GRPCPort: getEnv("GRPC_SERVER_PORT", "50051"),
 		},
+		Extractor: Extractor{
+			Algorithm:             getEnv("TAG_EXTRACTOR", extractor.Algorithm),
+			Available:             getEnvList("TAG_EXTRACTORS", extractor.Available),
+			RefreshInterval:       getEnvDuration("TFIDF_REFRESH_INTERVAL", extractor.RefreshInterval),
+			MaxPhraseWords:        getEnvInt("RAKE_MAX_PHRASE_WORDS", extractor.MaxPhraseWords),
+			TextRankWindow:        getEnvInt("TEXTRANK_WINDOW", extractor.TextRankWindow),
+			Stemming:              getEnvBool("TAG_STEMMING", extractor.Stemming),
+			TitleWeight:           getEnvFloat("TAG_TITLE_WEIGHT", extractor.TitleWeight),
+			BodyWeight:            getEnvFloat("TAG_BODY_WEIGHT", extractor.BodyWeight),
+			SummaryWeight:         getEnvFloat("TAG_SUMMARY_WEIGHT", extractor.SummaryWeight),
+			HeadingWeight:         getEnvFloat("TAG_HEADING_WEIGHT", extractor.HeadingWeight),
+			PositionHalfLife:      getEnvInt("TAG_POSITION_HALF_LIFE", extractor.PositionHalfLife),
+			ProtectedTokens:       getEnvBool("TAG_PROTECTED_TOKENS", extractor.ProtectedTokens),
+			ProtectedPatternsFile: getEnv("TAG_PROTECTED_PATTERNS_FILE", ""),
+			MaxTags:               getEnvInt("TAG_MAX_TAGS", extractor.MaxTags),
+			MinFrequency:          getEnvInt("TAG_MIN_FREQUENCY", extractor.MinFrequency),
+			MinScore:              getEnvFloat("TAG_MIN_SCORE", extractor.MinScore),
+		},
+		StopWords: StopWords{
+			Paths:          getEnvList("STOPWORDS_PATHS", nil),
+			ReloadInterval: getEnvDuration("STOPWORDS_RELOAD_INTERVAL", 30*time.Second),
+		},
+		Taxonomy: Taxonomy{
+			Enabled:         getEnvBool("TAXONOMY_ENABLED", false),
+			Enforce:         getEnvBool("TAXONOMY_ENFORCE", false),
+			RefreshInterval: getEnvDuration("TAXONOMY_REFRESH_INTERVAL", 5*time.Minute),
+		},
+		Policy: Policy{
+			File: getEnv("TAG_POLICY_FILE", ""),
+		},
+		Entities: Entities{
+			Enabled: getEnvBool("TAG_ENTITIES_ENABLED", false),
+			Boost:   getEnvFloat("TAG_ENTITY_BOOST", 1.5),
+		},
+		Gazetteer: Gazetteer{
+			Paths: getEnvList("GAZETTEER_PATHS", nil),
+			Boost: getEnvFloat("GAZETTEER_BOOST", 1.5),
+		},
+		Ensemble: Ensemble{
+			Weights: getEnvWeights("TAG_ENSEMBLE"),
+			Method:  getEnv("TAG_ENSEMBLE_METHOD", "rrf"),
+			RRFK:    getEnvFloat("TAG_ENSEMBLE_RRF_K", 60),
+		},
+		Classifier: Classifier{
+			ModelFile:      getEnv("CLASSIFIER_MODEL_FILE", ""),
+			MinProbability: getEnvFloat("CLASSIFIER_MIN_PROBABILITY", 0.5),
+			MaxCategories:  getEnvInt("CLASSIFIER_MAX_CATEGORIES", 1),
+			Alpha:          getEnvFloat("CLASSIFIER_ALPHA", 1),
+		},
+		Summarizer: Summarizer{
+			Sentences: getEnvInt("SUMMARY_SENTENCES", 3),
+		},
+		Topics: Topics{
+			Count:        getEnvInt("TOPICS_COUNT", 20),
+			Iterations:   getEnvInt("TOPICS_ITERATIONS", 200),
+			Alpha:        getEnvFloat("TOPICS_ALPHA", 0.1),
+			Beta:         getEnvFloat("TOPICS_BETA", 0.01),
+			MinDocuments: getEnvInt("TOPICS_MIN_DOCUMENTS", 2),
+			Words:        getEnvInt("TOPICS_WORDS", 10),
+			MinWeight:    getEnvFloat("TOPICS_MIN_WEIGHT", 0.1),
+			Seed:         getEnvInt("TOPICS_SEED", 1),
+		},
+		Feedback: Feedback{
+			Enabled:         getEnvBool("FEEDBACK_ENABLED", false),
+			Strength:        getEnvFloat("FEEDBACK_STRENGTH", 1),
+			Smoothing:       getEnvFloat("FEEDBACK_SMOOTHING", 5),
+			RefreshInterval: getEnvDuration("FEEDBACK_REFRESH_INTERVAL", 5*time.Minute),
+		},
 	}
 }
 
@@ -22,3 +96,93 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("invalid duration for %s: %v, using %v", key, err, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("invalid integer for %s: %v, using %d", key, err, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("invalid number for %s: %v, using %v", key, err, defaultValue)
+		return defaultValue
+	}
+	return f
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("invalid boolean for %s: %v, using %v", key, err, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+func getEnvList(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
+// getEnvWeights reads a list of "name:weight" pairs. A name without a weight
+// weighs 1.
+func getEnvWeights(key string) map[string]float64 {
+	values := getEnvList(key, nil)
+	if len(values) == 0 {
+		return nil
+	}
+	weights := make(map[string]float64, len(values))
+	for _, value := range values {
+		name, weight, found := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !found {
+			weights[name] = 1
+			continue
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil {
+			log.Printf("invalid weight for %s in %s: %v, ignoring %s", name, key, err, key)
+			return nil
+		}
+		weights[name] = w
+	}
+	return weights
+}
@@ -5,18 +5,201 @@ import (
 )
 
 type Article struct {
-	ID        string    `bson:"_id,omitempty" json:"id"`
-	Title     string    `bson:"title" json:"title"`
-	Body      string    `bson:"body" json:"body"`
-	Tags      []string  `bson:"tags" json:"tags"`
+	ID           string        `bson:"_id,omitempty" json:"id"`
+	Title        string        `bson:"title" json:"title"`
+	Body         string        `bson:"body" json:"body"`
+	ContentType  string        `bson:"content_type,omitempty" json:"content_type,omitempty"`
+	Language     string        `bson:"language" json:"language"`
+	Tenant       string        `bson:"tenant,omitempty" json:"tenant,omitempty"`
+	Source       string        `bson:"source,omitempty" json:"source,omitempty"`
+	Tags         []string      `bson:"tags" json:"tags"`
+	RejectedTags []RejectedTag `bson:"rejected_tags,omitempty" json:"rejected_tags,omitempty"`
+	Entities     []TagEntity   `bson:"entities,omitempty" json:"entities,omitempty"`
+	// Categories are assigned by editors and train the category classifier;
+	// PredictedCategories are assigned by the classifier
+	Categories          []string        `bson:"categories,omitempty" json:"categories,omitempty"`
+	PredictedCategories []CategoryScore `bson:"predicted_categories,omitempty" json:"predicted_categories,omitempty"`
+	// Topics is the topic mixture assigned by the topic model, largest
+	// weight first
+	Topics []TopicWeight `bson:"topics,omitempty" json:"topics,omitempty"`
+	// Extractor and ExtractorVersion name the extractor that produced Tags
+	Extractor        string `bson:"extractor,omitempty" json:"extractor,omitempty"`
+	ExtractorVersion string `bson:"extractor_version,omitempty" json:"extractor_version,omitempty"`
+	// Summary holds the key sentences of the body in reading order
+	Summary string `bson:"summary,omitempty" json:"summary,omitempty"`
+	// Explanations are only stored when requested
+	Explanations []TagExplanation `bson:"explanations,omitempty" json:"explanations,omitempty"`
+	CreatedAt    time.Time        `bson:"created_at" json:"created_at"`
+}
+
+// RejectedTag is an extracted tag that a tag policy kept off the article,
+// with the reason it was rejected.
+type RejectedTag struct {
+	Tag    string `bson:"tag" json:"tag"`
+	Reason string `bson:"reason" json:"reason"`
+}
+
+// Editor decisions on the tags of a stored article.
+const (
+	FeedbackAccept = "accept"
+	FeedbackReject = "reject"
+	FeedbackAdd    = "add"
+)
+
+// TagFeedback is an editor decision on a tag of a stored article. Source is
+// the source of the article, which decisions are learned per.
+type TagFeedback struct {
+	ArticleID string    `bson:"article_id" json:"article_id"`
+	Source    string    `bson:"source" json:"source"`
+	Tag       string    `bson:"tag" json:"tag"`
+	Action    string    `bson:"action" json:"action"`
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 }
 
+// TagFeedbackStats counts the decisions on a tag of a source.
+type TagFeedbackStats struct {
+	Source   string `bson:"source" json:"source"`
+	Tag      string `bson:"tag" json:"tag"`
+	Accepted int    `bson:"accepted" json:"accepted"`
+	Rejected int    `bson:"rejected" json:"rejected"`
+	Added    int    `bson:"added" json:"added"`
+}
+
+// SummarySentence is a sentence picked for the summary of an article, with
+// its TextRank score and its byte offsets in the plain text of the body.
+type SummarySentence struct {
+	Text  string  `json:"text"`
+	Score float64 `json:"score"`
+	Start int     `json:"start"`
+	End   int     `json:"end"`
+}
+
+// CategoryScore is a category predicted for an article with its probability.
+type CategoryScore struct {
+	Category    string  `bson:"category" json:"category"`
+	Probability float64 `bson:"probability" json:"probability"`
+}
+
+// Topic is a topic of the topic model with its most probable words. Weight
+// is its share of the words of the archive and Articles the number of
+// articles it was assigned to.
+type Topic struct {
+	ID       int         `bson:"_id" json:"id"`
+	Weight   float64     `bson:"weight" json:"weight"`
+	Articles int         `bson:"articles" json:"articles"`
+	Words    []TopicWord `bson:"words" json:"words"`
+}
+
+// TopicWord is a word of a topic with its probability in the topic.
+type TopicWord struct {
+	Word        string  `bson:"word" json:"word"`
+	Probability float64 `bson:"probability" json:"probability"`
+}
+
+// TopicWeight is the weight of a topic in the topic mixture of an article.
+type TopicWeight struct {
+	Topic  int     `bson:"topic" json:"topic"`
+	Weight float64 `bson:"weight" json:"weight"`
+}
+
+// Entity types of tags that name something.
+const (
+	EntityAcronym    = "acronym"
+	EntityProperNoun = "proper_noun"
+)
+
+// TagEntity records that a tag of an article names an entity of Type. ID is
+// the canonical ID of entities linked to a gazetteer.
+type TagEntity struct {
+	Tag  string `bson:"tag" json:"tag"`
+	Type string `bson:"type" json:"type"`
+	ID   string `bson:"id,omitempty" json:"id,omitempty"`
+}
+
+// Names of the document fields tag occurrences are found in.
+const (
+	FieldTitle    = "title"
+	FieldSummary  = "summary"
+	FieldHeadings = "headings"
+	FieldBody     = "body"
+)
+
+// TagExplanation tells why a tag was extracted. Score is the score the
+// extractor gave it; the other figures break down a term-frequency score:
+// Frequency occurrences weigh FieldWeight together, early body occurrences
+// multiply that by PositionBoost and tf-idf multiplies it by IDF, which is 1
+// for other extractors.
+type TagExplanation struct {
+	Tag           string          `bson:"tag" json:"tag"`
+	Score         float64         `bson:"score" json:"score"`
+	Frequency     int             `bson:"frequency" json:"frequency"`
+	IDF           float64         `bson:"idf" json:"idf"`
+	FieldWeight   float64         `bson:"field_weight" json:"field_weight"`
+	PositionBoost float64         `bson:"position_boost" json:"position_boost"`
+	Occurrences   []TagOccurrence `bson:"occurrences" json:"occurrences"`
+}
+
+// TagOccurrence is an occurrence of a tag at the byte offsets [Start, End) of
+// a field. Offsets refer to the plain text of the field, which is the text as
+// submitted unless HTML or Markdown markup was stripped from it.
+type TagOccurrence struct {
+	Field string `bson:"field" json:"field"`
+	Start int    `bson:"start" json:"start"`
+	End   int    `bson:"end" json:"end"`
+}
+
+// GazetteerEntry is a known entity of a local gazetteer: its canonical ID and
+// name, its type such as "country" or "company" and the other names it goes
+// by.
+type GazetteerEntry struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Aliases []string `json:"aliases"`
+}
+
 type TagFrequency struct {
 	Tag       string `bson:"_id" json:"tag"`
 	Frequency int    `bson:"frequency" json:"frequency"`
 }
 
+// ScoredTag is a ranked tag. Type is the entity type of tags that name an
+// entity and empty otherwise; ID is set for entities linked to a gazetteer.
+// Frequency is the number of times the tag occurs in the document, or zero if
+// the extractor does not count occurrences.
+type ScoredTag struct {
+	Tag       string  `bson:"tag" json:"tag"`
+	Score     float64 `bson:"score" json:"score"`
+	Type      string  `bson:"type,omitempty" json:"type,omitempty"`
+	ID        string  `bson:"id,omitempty" json:"id,omitempty"`
+	Frequency int     `bson:"frequency,omitempty" json:"frequency,omitempty"`
+}
+
+type DocumentFrequencies struct {
+	TotalDocuments int            `json:"total_documents"`
+	Terms          map[string]int `json:"terms"`
+}
+
+// VocabularyTerm is a canonical tag of the controlled vocabulary together with
+// the spellings that map to it.
+type VocabularyTerm struct {
+	Tag     string   `bson:"_id" json:"tag"`
+	Aliases []string `bson:"aliases" json:"aliases"`
+}
+
+// Document is the structured input of tag extraction. Its fields hold plain
+// text and each is weighted separately. Language, Tenant and Source are
+// optional; an empty Language is detected from the text.
+type Document struct {
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	Summary  string `json:"summary"`
+	Headings string `json:"headings"`
+	Language string `json:"language"`
+	Tenant   string `json:"tenant"`
+	Source   string `json:"source"`
+}
+
 type ProcessArticleRequest struct {
 	Title string `json:"title"`
 	Body  string `json:"body"`
@@ -2,19 +2,61 @@ package port
 
 import (
 	"context"
+	"errors"
 
 	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
 )
 
+// ErrArticleNotFound is returned for an article ID that is not stored
+var ErrArticleNotFound = errors.New("article not found")
+
 // articleRepository defines the interface for article data operations
 type ArticleRepository interface {
 	SaveArticle(ctx context.Context, article *entity.Article) error
 	GetTopTags(ctx context.Context, limit int) ([]entity.TagFrequency, error)
 }
 
+// categorizedArticleRepository defines the interface for reading the articles editors assigned categories to
+type CategorizedArticleRepository interface {
+	ForEachCategorizedArticle(ctx context.Context, fn func(article *entity.Article) error) error
+}
+
+// topicModelRepository defines the interface for the articles the topic model is trained on and where it is saved
+type TopicModelRepository interface {
+	ForEachArticle(ctx context.Context, fn func(article *entity.Article) error) error
+	SaveTopics(ctx context.Context, topics []entity.Topic) error
+	SaveArticleTopics(ctx context.Context, mixtures map[string][]entity.TopicWeight) error
+}
+
+// topicRepository defines the interface for reading the topics of the topic model and the articles assigned to them
+type TopicRepository interface {
+	GetTopics(ctx context.Context) ([]entity.Topic, error)
+	GetArticlesByTopic(ctx context.Context, topic int, limit int) ([]*entity.Article, error)
+}
+
+// tagFeedbackRepository defines the interface for editor decisions on the tags of stored articles
+type TagFeedbackRepository interface {
+	GetArticle(ctx context.Context, id string) (*entity.Article, error)
+	UpdateArticleTags(ctx context.Context, id string, tags []string) error
+	SaveTagFeedback(ctx context.Context, feedback []entity.TagFeedback) error
+	GetTagFeedbackStats(ctx context.Context) ([]entity.TagFeedbackStats, error)
+}
+
+// documentFrequencyRepository defines the interface for corpus statistics used by tf-idf
+type DocumentFrequencyRepository interface {
+	GetDocumentFrequencies(ctx context.Context) (*entity.DocumentFrequencies, error)
+}
+
+// vocabularyRepository defines the interface for the controlled tag vocabulary
+type VocabularyRepository interface {
+	GetVocabulary(ctx context.Context) ([]entity.VocabularyTerm, error)
+	SaveVocabularyTerm(ctx context.Context, term entity.VocabularyTerm) error
+}
+
 // tagExtractor defines the interface for tag extraction logic
 type TagExtractor interface {
-	ExtractTags(title, body string) []string
+	ExtractTags(doc entity.Document) []string
+	ExtractScoredTags(doc entity.Document) []entity.ScoredTag
 }
 
 // articleService defines the interface for article business logic
@@ -22,3 +64,8 @@ type ArticleService interface {
 	ProcessArticles(ctx context.Context, articles []entity.ProcessArticleRequest) (int, error)
 	GetTopTags(ctx context.Context, limit int) ([]entity.TagFrequency, error)
 }
+
+// tagExplainer is implemented by tag extractors that can explain their tags
+type TagExplainer interface {
+	ExplainTags(doc entity.Document, scoredTags []entity.ScoredTag) []entity.TagExplanation
+}
@@ -2,16 +2,32 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"net"
 
 	"github.com/SaeedMPro/article-tag-extractor/internal/app"
 	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
 	pb "github.com/SaeedMPro/article-tag-extractor/internal/proto"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// maxTagsPerArticle caps the max_tags of a request.
+const maxTagsPerArticle = 100
+
+// maxSummarySentences caps the summary length of a request.
+const maxSummarySentences = 20
+
+// maxTopicWords and maxTopicArticles cap the topic words and articles of a
+// request.
+const (
+	maxTopicWords    = 100
+	maxTopicArticles = 100
+)
+
 type Server struct {
 	pb.UnimplementedArticleServiceServer
 	grpcServer *grpc.Server
@@ -39,29 +55,277 @@ func (s *Server) ProcessArticles(ctx context.Context, req *pb.ProcessArticlesReq
 	if len(req.Articles) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "no articles provided")
 	}
+	opts, err := processOptions(req.MaxTags, req.MinFrequency, req.MinScore)
+	if err != nil {
+		return nil, err
+	}
+	extractor, err := s.service.Extractor(req.Extractor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.Extractor = extractor.Name
+	opts.Explain = req.Explain
+	if opts.SummarySentences, err = summarySentences(req.SummarySentences); err != nil {
+		return nil, err
+	}
 
 	// convert protobuf articles to domain entities
 	var articles []*entity.Article
 	for _, article := range req.Articles {
-		articles = append(articles, &entity.Article{
-			Title: article.Title,
-			Body:  article.Body,
-		})
+		a, err := toArticle(article)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, a)
 	}
 
 	// process articles into service
-	totalArticleProcessed, err := s.service.ProcessArticles(ctx, articles)
+	totalArticleProcessed, err := s.service.ProcessArticlesWithOptions(ctx, articles, opts)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed in process articles: %v", err)
 	}
 
+	articleIDs := make([]string, 0, len(articles))
+	summaries := make([]string, 0, len(articles))
+	for _, a := range articles {
+		articleIDs = append(articleIDs, a.ID)
+		summaries = append(summaries, a.Summary)
+	}
+
 	res := &pb.ProcessArticlesResponse{
-		TotalProcessed: int32(totalArticleProcessed),
+		TotalProcessed:   int32(totalArticleProcessed),
+		Extractor:        extractor.Name,
+		ExtractorVersion: extractor.Version,
+		ArticleIds:       articleIDs,
+		Summaries:        summaries,
 	}
 
 	return res, nil
 }
 
+func (s *Server) ExplainTags(ctx context.Context, req *pb.ExplainTagsRequest) (*pb.ExplainTagsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.Article == nil {
+		return nil, status.Error(codes.InvalidArgument, "no article provided")
+	}
+	opts, err := processOptions(req.MaxTags, req.MinFrequency, req.MinScore)
+	if err != nil {
+		return nil, err
+	}
+	extractor, err := s.service.Extractor(req.Extractor)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.Extractor = extractor.Name
+
+	article, err := toArticle(req.Article)
+	if err != nil {
+		return nil, err
+	}
+	explanations, err := s.service.ExplainTags(ctx, article, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to explain tags: %v", err)
+	}
+
+	// convert to protobuf
+	var pbTags []*pb.TagExplanation
+	for _, e := range explanations {
+		var occurrences []*pb.TagOccurrence
+		for _, o := range e.Occurrences {
+			occurrences = append(occurrences, &pb.TagOccurrence{
+				Field: o.Field,
+				Start: int32(o.Start),
+				End:   int32(o.End),
+			})
+		}
+		pbTags = append(pbTags, &pb.TagExplanation{
+			Tag:           e.Tag,
+			Score:         e.Score,
+			Frequency:     int32(e.Frequency),
+			Idf:           e.IDF,
+			FieldWeight:   e.FieldWeight,
+			PositionBoost: e.PositionBoost,
+			Occurrences:   occurrences,
+		})
+	}
+
+	var pbCategories []*pb.CategoryScore
+	for _, cs := range article.PredictedCategories {
+		pbCategories = append(pbCategories, &pb.CategoryScore{
+			Category:    cs.Category,
+			Probability: cs.Probability,
+		})
+	}
+
+	return &pb.ExplainTagsResponse{
+		Extractor:        extractor.Name,
+		ExtractorVersion: extractor.Version,
+		Language:         article.Language,
+		Tags:             pbTags,
+		Categories:       pbCategories,
+	}, nil
+}
+
+func (s *Server) SubmitTagFeedback(ctx context.Context, req *pb.SubmitTagFeedbackRequest) (*pb.SubmitTagFeedbackResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.ArticleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "no article id provided")
+	}
+	if len(req.Accept)+len(req.Reject)+len(req.Add) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no tag decisions provided")
+	}
+	article, err := s.service.SubmitTagFeedback(ctx, req.ArticleId, req.Accept, req.Reject, req.Add)
+	switch {
+	case errors.Is(err, port.ErrArticleNotFound):
+		return nil, status.Errorf(codes.NotFound, "article %s not found", req.ArticleId)
+	case errors.Is(err, app.ErrConflictingFeedback):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrFeedbackDisabled):
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to submit tag feedback: %v", err)
+	}
+
+	return &pb.SubmitTagFeedbackResponse{
+		Tags: article.Tags,
+	}, nil
+}
+
+func (s *Server) Summarize(ctx context.Context, req *pb.SummarizeRequest) (*pb.SummarizeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.Article == nil {
+		return nil, status.Error(codes.InvalidArgument, "no article provided")
+	}
+	sentences, err := summarySentences(req.Sentences)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := toArticle(req.Article)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.service.Summarize(ctx, article, sentences)
+	switch {
+	case errors.Is(err, app.ErrSummarizerDisabled):
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to summarize article: %v", err)
+	}
+
+	// convert to protobuf
+	var pbSentences []*pb.SummarySentence
+	for _, sentence := range summary {
+		pbSentences = append(pbSentences, &pb.SummarySentence{
+			Text:  sentence.Text,
+			Score: sentence.Score,
+			Start: int32(sentence.Start),
+			End:   int32(sentence.End),
+		})
+	}
+
+	return &pb.SummarizeResponse{
+		Summary:   article.Summary,
+		Sentences: pbSentences,
+		Language:  article.Language,
+	}, nil
+}
+
+func (s *Server) ListTopics(ctx context.Context, req *pb.ListTopicsRequest) (*pb.ListTopicsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.Words < 0 {
+		return nil, status.Error(codes.InvalidArgument, "words cannot be negative")
+	}
+	if req.Words > maxTopicWords {
+		return nil, status.Errorf(codes.InvalidArgument, "words cannot exceed %d", maxTopicWords)
+	}
+
+	topics, err := s.service.ListTopics(ctx, int(req.Words))
+	switch {
+	case errors.Is(err, app.ErrTopicsDisabled):
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to list topics: %v", err)
+	}
+
+	// convert to protobuf
+	var pbTopics []*pb.Topic
+	for _, topic := range topics {
+		var pbWords []*pb.TopicWord
+		for _, word := range topic.Words {
+			pbWords = append(pbWords, &pb.TopicWord{
+				Word:        word.Word,
+				Probability: word.Probability,
+			})
+		}
+		pbTopics = append(pbTopics, &pb.Topic{
+			Id:       int32(topic.ID),
+			Weight:   topic.Weight,
+			Articles: int32(topic.Articles),
+			Words:    pbWords,
+		})
+	}
+
+	return &pb.ListTopicsResponse{
+		Topics: pbTopics,
+	}, nil
+}
+
+func (s *Server) GetArticlesByTopic(ctx context.Context, req *pb.GetArticlesByTopicRequest) (*pb.GetArticlesByTopicResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.Topic < 0 {
+		return nil, status.Error(codes.InvalidArgument, "topic cannot be negative")
+	}
+	if req.Limit <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrong limit provided")
+	}
+	if req.Limit > maxTopicArticles {
+		return nil, status.Errorf(codes.InvalidArgument, "limit cannot exceed %d", maxTopicArticles)
+	}
+
+	articles, err := s.service.GetArticlesByTopic(ctx, int(req.Topic), int(req.Limit))
+	switch {
+	case errors.Is(err, app.ErrTopicsDisabled):
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to get articles by topic: %v", err)
+	}
+
+	// convert to protobuf
+	var pbArticles []*pb.TopicArticle
+	for _, article := range articles {
+		pbArticle := &pb.TopicArticle{
+			Id:    article.ID,
+			Title: article.Title,
+			Tags:  article.Tags,
+		}
+		for _, w := range article.Topics {
+			if w.Topic == int(req.Topic) {
+				pbArticle.Weight = w.Weight
+			}
+			pbArticle.Topics = append(pbArticle.Topics, &pb.TopicWeight{
+				Topic:  int32(w.Topic),
+				Weight: w.Weight,
+			})
+		}
+		pbArticles = append(pbArticles, pbArticle)
+	}
+
+	return &pb.GetArticlesByTopicResponse{
+		Articles: pbArticles,
+	}, nil
+}
+
 func (s *Server) GetTopTags(ctx context.Context, req *pb.GetTopTagsRequest) (*pb.GetTopTagsResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
@@ -93,3 +357,50 @@ func (s *Server) GetTopTags(ctx context.Context, req *pb.GetTopTagsRequest) (*pb
 func (s *Server) GracefulStop() {
 	s.grpcServer.GracefulStop()
 }
+
+// processOptions validates the tag limits of a request.
+func processOptions(maxTags, minFrequency int32, minScore float64) (app.ProcessOptions, error) {
+	limits := app.TagLimits{
+		MaxTags:      int(maxTags),
+		MinFrequency: int(minFrequency),
+		MinScore:     minScore,
+	}
+	if err := limits.Validate(); err != nil {
+		return app.ProcessOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if limits.MaxTags > maxTagsPerArticle {
+		return app.ProcessOptions{}, status.Errorf(codes.InvalidArgument, "max_tags cannot exceed %d", maxTagsPerArticle)
+	}
+	return app.ProcessOptions{
+		MaxTags:      limits.MaxTags,
+		MinFrequency: limits.MinFrequency,
+		MinScore:     limits.MinScore,
+	}, nil
+}
+
+// summarySentences validates the summary length of a request.
+func summarySentences(sentences int32) (int, error) {
+	if sentences < 0 {
+		return 0, status.Error(codes.InvalidArgument, "summary sentences cannot be negative")
+	}
+	if sentences > maxSummarySentences {
+		return 0, status.Errorf(codes.InvalidArgument, "summary sentences cannot exceed %d", maxSummarySentences)
+	}
+	return int(sentences), nil
+}
+
+// toArticle converts a protobuf article to a domain entity.
+func toArticle(article *pb.Article) (*entity.Article, error) {
+	contentType, ok := utils.ParseContentType(article.ContentType)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported content type: %s", article.ContentType)
+	}
+	return &entity.Article{
+		Title:       article.Title,
+		Body:        article.Body,
+		ContentType: contentType,
+		Tenant:      article.Tenant,
+		Source:      article.Source,
+		Categories:  article.Categories,
+	}, nil
+}
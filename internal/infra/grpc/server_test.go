@@ -3,10 +3,13 @@ package grpc
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/SaeedMPro/article-tag-extractor/internal/app"
+	"github.com/SaeedMPro/article-tag-extractor/internal/config"
 	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
 	pb "github.com/SaeedMPro/article-tag-extractor/internal/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -37,6 +40,7 @@ func (m *MockArticleRepository) SaveArticle(ctx context.Context, article *entity
 	if m.saveError != nil {
 		return m.saveError
 	}
+	article.ID = "id-" + article.Title
 	m.articles = append(m.articles, *article)
 	return nil
 }
@@ -57,7 +61,7 @@ type MockTagExtractor struct {
 	tags []string
 }
 
-func (m *MockTagExtractor) ExtractTags(title, body string) []string {
+func (m *MockTagExtractor) ExtractTags(doc entity.Document) []string {
 	return m.tags
 }
 
@@ -65,6 +69,14 @@ func (m *MockTagExtractor) ExtractTagsConcurrently(title, body string) []string
 	return m.tags
 }
 
+func (m *MockTagExtractor) ExtractScoredTags(doc entity.Document) []entity.ScoredTag {
+	scoredTags := make([]entity.ScoredTag, 0, len(m.tags))
+	for i, tag := range m.tags {
+		scoredTags = append(scoredTags, entity.ScoredTag{Tag: tag, Score: float64(len(m.tags) - i)})
+	}
+	return scoredTags
+}
+
 func (m *MockArticleService) ProcessArticles(ctx context.Context, articles []*entity.Article) (int, error) {
 	m.processCallCount++
 	return m.processArticlesResult, m.processArticlesError
@@ -117,6 +129,107 @@ func TestServer_ProcessArticles(t *testing.T) {
 			expectedCode:  codes.InvalidArgument,
 			expectedCount: 0,
 		},
+		{
+			name: "html content",
+			request: &pb.ProcessArticlesRequest{
+				Articles: []*pb.Article{
+					{Title: "Test Article", Body: "<p>This is a <b>test</b> article</p>", ContentType: "text/html; charset=utf-8"},
+				},
+			},
+			mockResult:    1,
+			mockError:     nil,
+			expectedCode:  codes.OK,
+			expectedCount: 1,
+		},
+		{
+			name: "unsupported content type",
+			request: &pb.ProcessArticlesRequest{
+				Articles: []*pb.Article{
+					{Title: "Test Article", Body: "{\\rtf1 test}", ContentType: "application/rtf"},
+				},
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "negative max tags",
+			request: &pb.ProcessArticlesRequest{
+				Articles: []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				MaxTags:  -1,
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "negative min score",
+			request: &pb.ProcessArticlesRequest{
+				Articles: []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				MinScore: -0.5,
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "too many tags",
+			request: &pb.ProcessArticlesRequest{
+				Articles: []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				MaxTags:  101,
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "negative min frequency",
+			request: &pb.ProcessArticlesRequest{
+				Articles:     []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				MinFrequency: -1,
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "too many summary sentences",
+			request: &pb.ProcessArticlesRequest{
+				Articles:         []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				SummarySentences: 21,
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "min score above 1",
+			request: &pb.ProcessArticlesRequest{
+				Articles: []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				MinScore: 1.5,
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
+		{
+			name: "unknown extractor",
+			request: &pb.ProcessArticlesRequest{
+				Articles:  []*pb.Article{{Title: "Test Article", Body: "This is a test article"}},
+				Extractor: "word2vec",
+			},
+			mockResult:    0,
+			mockError:     nil,
+			expectedCode:  codes.InvalidArgument,
+			expectedCount: 0,
+		},
 		{
 			name: "service error",
 			request: &pb.ProcessArticlesRequest{
@@ -190,6 +303,349 @@ func TestServer_ProcessArticles(t *testing.T) {
 	}
 }
 
+func TestServer_ProcessArticles_Extractor(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	extractors := app.NewExtractorRegistry(app.FrequencyExtractor)
+	extractors.Register(app.FrequencyExtractor, "1", &MockTagExtractor{tags: []string{"frequent"}})
+	extractors.Register(app.RakeExtractor, "2", &MockTagExtractor{tags: []string{"key phrase", "other phrase", "third phrase"}})
+	grpcServer := NewServer(app.NewArticleServiceWithRegistry(mockRepo, extractors))
+
+	response, err := grpcServer.ProcessArticles(context.Background(), &pb.ProcessArticlesRequest{
+		Articles:  []*pb.Article{{Title: "Test Article", Body: "This is a test article", Categories: []string{"technology"}}},
+		Extractor: app.RakeExtractor,
+		MaxTags:   2,
+		MinScore:  0.5,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Extractor != app.RakeExtractor || response.ExtractorVersion != "2" {
+		t.Errorf("Expected extractor rake 2, got %s %s", response.Extractor, response.ExtractorVersion)
+	}
+	if len(response.ArticleIds) != 1 || response.ArticleIds[0] != "id-Test Article" {
+		t.Errorf("Expected the id of the saved article, got %v", response.ArticleIds)
+	}
+
+	if len(mockRepo.articles) != 1 {
+		t.Fatalf("Expected 1 saved article, got %d", len(mockRepo.articles))
+	}
+	article := mockRepo.articles[0]
+	if article.Extractor != app.RakeExtractor || article.ExtractorVersion != "2" {
+		t.Errorf("Expected the extractor to be saved, got %s %s", article.Extractor, article.ExtractorVersion)
+	}
+	if len(article.Categories) != 1 || article.Categories[0] != "technology" {
+		t.Errorf("Expected the editor categories to be saved, got %v", article.Categories)
+	}
+	// the third phrase scores a third of the top score, below min_score
+	if len(article.Tags) != 2 || article.Tags[0] != "key phrase" || article.Tags[1] != "other phrase" {
+		t.Errorf("Expected [key phrase other phrase], got %v", article.Tags)
+	}
+}
+
+func TestServer_ExplainTags(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	grpcServer := NewServer(app.NewArticleService(mockRepo))
+	article := &pb.Article{Title: "Go Generics", Body: "Generics arrived in Go 1.18, and now generics are everywhere."}
+
+	tests := []struct {
+		name         string
+		request      *pb.ExplainTagsRequest
+		expectedCode codes.Code
+	}{
+		{name: "nil request", request: nil, expectedCode: codes.InvalidArgument},
+		{name: "no article", request: &pb.ExplainTagsRequest{}, expectedCode: codes.InvalidArgument},
+		{name: "negative max tags", request: &pb.ExplainTagsRequest{Article: article, MaxTags: -1}, expectedCode: codes.InvalidArgument},
+		{name: "unknown extractor", request: &pb.ExplainTagsRequest{Article: article, Extractor: "lda"}, expectedCode: codes.InvalidArgument},
+		{name: "unsupported content type", request: &pb.ExplainTagsRequest{Article: &pb.Article{Title: "Go", ContentType: "application/pdf"}}, expectedCode: codes.InvalidArgument},
+		{name: "valid request", request: &pb.ExplainTagsRequest{Article: article, MaxTags: 1}, expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := grpcServer.ExplainTags(context.Background(), tt.request)
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("Expected code %v, got %v", tt.expectedCode, err)
+			}
+			if tt.expectedCode != codes.OK {
+				return
+			}
+
+			if len(response.Tags) != 1 || response.Tags[0].Tag != "generics" {
+				t.Fatalf("Expected one explanation of generics, got %v", response.Tags)
+			}
+			occurrences := response.Tags[0].Occurrences
+			if len(occurrences) != 3 || occurrences[0].Field != "title" || occurrences[0].Start != 3 || occurrences[0].End != 11 {
+				t.Errorf("Expected the occurrences of generics, got %v", occurrences)
+			}
+			if response.Language != "en" {
+				t.Errorf("Expected language en, got %s", response.Language)
+			}
+		})
+	}
+
+	if len(mockRepo.articles) != 0 {
+		t.Errorf("Expected nothing to be saved, got %d articles", len(mockRepo.articles))
+	}
+}
+
+func TestServer_Summarize(t *testing.T) {
+	mockRepo := &MockArticleRepository{}
+	service := app.NewArticleService(mockRepo)
+	service.Summarizer = app.NewSummarizer(config.Summarizer{Sentences: 1}, config.Extractor{})
+	grpcServer := NewServer(service)
+	article := &pb.Article{
+		Title: "Go",
+		Body:  "Go is a language. The weather is nice. Go has generics and Go compiles fast.",
+	}
+
+	tests := []struct {
+		name         string
+		request      *pb.SummarizeRequest
+		expectedCode codes.Code
+	}{
+		{name: "nil request", request: nil, expectedCode: codes.InvalidArgument},
+		{name: "no article", request: &pb.SummarizeRequest{}, expectedCode: codes.InvalidArgument},
+		{name: "negative sentences", request: &pb.SummarizeRequest{Article: article, Sentences: -1}, expectedCode: codes.InvalidArgument},
+		{name: "too many sentences", request: &pb.SummarizeRequest{Article: article, Sentences: 21}, expectedCode: codes.InvalidArgument},
+		{name: "valid request", request: &pb.SummarizeRequest{Article: article, Sentences: 2}, expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := grpcServer.Summarize(context.Background(), tt.request)
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("Expected code %v, got %v", tt.expectedCode, err)
+			}
+			if tt.expectedCode != codes.OK {
+				return
+			}
+
+			expected := "Go is a language. Go has generics and Go compiles fast."
+			if response.Summary != expected {
+				t.Errorf("Expected summary %q, got %q", expected, response.Summary)
+			}
+			if len(response.Sentences) != 2 || response.Sentences[1].Start != 39 {
+				t.Errorf("Expected 2 sentences with offsets, got %v", response.Sentences)
+			}
+		})
+	}
+
+	// processed articles carry their summary in the response and when saved
+	response, err := grpcServer.ProcessArticles(context.Background(), &pb.ProcessArticlesRequest{Articles: []*pb.Article{article}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := "Go is a language."
+	if len(response.Summaries) != 1 || response.Summaries[0] != expected {
+		t.Errorf("Expected summaries [%s], got %v", expected, response.Summaries)
+	}
+	if len(mockRepo.articles) != 1 || mockRepo.articles[0].Summary != expected {
+		t.Errorf("Expected the summary to be saved, got %v", mockRepo.articles)
+	}
+}
+
+func TestServer_Summarize_Disabled(t *testing.T) {
+	grpcServer := NewServer(app.NewArticleService(&MockArticleRepository{}))
+
+	_, err := grpcServer.Summarize(context.Background(), &pb.SummarizeRequest{Article: &pb.Article{Title: "Go", Body: "Go is fast."}})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected code %v, got %v", codes.Unimplemented, err)
+	}
+}
+
+// MockTagFeedbackRepository is a mock implementation of TagFeedbackRepository
+type MockTagFeedbackRepository struct {
+	articles map[string]*entity.Article
+	feedback []entity.TagFeedback
+}
+
+func (m *MockTagFeedbackRepository) GetArticle(ctx context.Context, id string) (*entity.Article, error) {
+	article, ok := m.articles[id]
+	if !ok {
+		return nil, port.ErrArticleNotFound
+	}
+	copied := *article
+	return &copied, nil
+}
+
+func (m *MockTagFeedbackRepository) UpdateArticleTags(ctx context.Context, id string, tags []string) error {
+	m.articles[id].Tags = tags
+	return nil
+}
+
+func (m *MockTagFeedbackRepository) SaveTagFeedback(ctx context.Context, feedback []entity.TagFeedback) error {
+	m.feedback = append(m.feedback, feedback...)
+	return nil
+}
+
+func (m *MockTagFeedbackRepository) GetTagFeedbackStats(ctx context.Context) ([]entity.TagFeedbackStats, error) {
+	return nil, nil
+}
+
+func TestServer_SubmitTagFeedback(t *testing.T) {
+	feedbackRepo := &MockTagFeedbackRepository{articles: map[string]*entity.Article{
+		"a1": {ID: "a1", Source: "blog", Tags: []string{"go", "article"}},
+	}}
+	service := app.NewArticleService(&MockArticleRepository{})
+	service.Feedback = feedbackRepo
+	grpcServer := NewServer(service)
+
+	tests := []struct {
+		name         string
+		request      *pb.SubmitTagFeedbackRequest
+		expectedCode codes.Code
+	}{
+		{name: "nil request", request: nil, expectedCode: codes.InvalidArgument},
+		{name: "no article id", request: &pb.SubmitTagFeedbackRequest{Accept: []string{"go"}}, expectedCode: codes.InvalidArgument},
+		{name: "no decisions", request: &pb.SubmitTagFeedbackRequest{ArticleId: "a1"}, expectedCode: codes.InvalidArgument},
+		{name: "accepted and rejected", request: &pb.SubmitTagFeedbackRequest{ArticleId: "a1", Accept: []string{"go"}, Reject: []string{"Go"}}, expectedCode: codes.InvalidArgument},
+		{name: "unknown article", request: &pb.SubmitTagFeedbackRequest{ArticleId: "missing", Accept: []string{"go"}}, expectedCode: codes.NotFound},
+		{name: "valid request", request: &pb.SubmitTagFeedbackRequest{ArticleId: "a1", Accept: []string{"go"}, Reject: []string{"article"}, Add: []string{"generics"}}, expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := grpcServer.SubmitTagFeedback(context.Background(), tt.request)
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("Expected code %v, got %v", tt.expectedCode, err)
+			}
+			if tt.expectedCode != codes.OK {
+				return
+			}
+
+			if len(response.Tags) != 2 || response.Tags[0] != "go" || response.Tags[1] != "generics" {
+				t.Errorf("Expected [go generics], got %v", response.Tags)
+			}
+			if len(feedbackRepo.feedback) != 3 {
+				t.Errorf("Expected 3 decisions to be saved, got %d", len(feedbackRepo.feedback))
+			}
+		})
+	}
+}
+
+func TestServer_SubmitTagFeedback_Disabled(t *testing.T) {
+	grpcServer := NewServer(app.NewArticleService(&MockArticleRepository{}))
+
+	_, err := grpcServer.SubmitTagFeedback(context.Background(), &pb.SubmitTagFeedbackRequest{ArticleId: "a1", Accept: []string{"go"}})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected code %v, got %v", codes.Unimplemented, err)
+	}
+}
+
+// MockTopicRepository is a mock implementation of TopicRepository
+type MockTopicRepository struct {
+	topics   []entity.Topic
+	articles []*entity.Article
+}
+
+func (m *MockTopicRepository) GetTopics(ctx context.Context) ([]entity.Topic, error) {
+	return slices.Clone(m.topics), nil
+}
+
+func (m *MockTopicRepository) GetArticlesByTopic(ctx context.Context, topic int, limit int) ([]*entity.Article, error) {
+	articles := []*entity.Article{}
+	for _, article := range m.articles {
+		if slices.ContainsFunc(article.Topics, func(w entity.TopicWeight) bool { return w.Topic == topic }) && len(articles) < limit {
+			articles = append(articles, article)
+		}
+	}
+	return articles, nil
+}
+
+func TestServer_ListTopics(t *testing.T) {
+	service := app.NewArticleService(&MockArticleRepository{})
+	service.Topics = &MockTopicRepository{topics: []entity.Topic{
+		{ID: 0, Weight: 0.7, Articles: 3, Words: []entity.TopicWord{{Word: "go", Probability: 0.2}, {Word: "compiler", Probability: 0.1}}},
+		{ID: 1, Weight: 0.3, Articles: 1, Words: []entity.TopicWord{{Word: "recipe", Probability: 0.3}}},
+	}}
+	grpcServer := NewServer(service)
+
+	tests := []struct {
+		name          string
+		request       *pb.ListTopicsRequest
+		expectedCode  codes.Code
+		expectedWords int
+	}{
+		{name: "nil request", request: nil, expectedCode: codes.InvalidArgument},
+		{name: "negative words", request: &pb.ListTopicsRequest{Words: -1}, expectedCode: codes.InvalidArgument},
+		{name: "too many words", request: &pb.ListTopicsRequest{Words: maxTopicWords + 1}, expectedCode: codes.InvalidArgument},
+		{name: "every word", request: &pb.ListTopicsRequest{}, expectedCode: codes.OK, expectedWords: 2},
+		{name: "top word", request: &pb.ListTopicsRequest{Words: 1}, expectedCode: codes.OK, expectedWords: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := grpcServer.ListTopics(context.Background(), tt.request)
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("Expected code %v, got %v", tt.expectedCode, err)
+			}
+			if tt.expectedCode != codes.OK {
+				return
+			}
+
+			if len(response.Topics) != 2 || response.Topics[0].Articles != 3 || response.Topics[1].Words[0].Word != "recipe" {
+				t.Fatalf("Unexpected topics %v", response.Topics)
+			}
+			if len(response.Topics[0].Words) != tt.expectedWords {
+				t.Errorf("Expected %d words, got %v", tt.expectedWords, response.Topics[0].Words)
+			}
+		})
+	}
+}
+
+func TestServer_GetArticlesByTopic(t *testing.T) {
+	service := app.NewArticleService(&MockArticleRepository{})
+	service.Topics = &MockTopicRepository{articles: []*entity.Article{
+		{ID: "a1", Title: "Go", Tags: []string{"go"}, Topics: []entity.TopicWeight{{Topic: 0, Weight: 0.9}}},
+		{ID: "a2", Title: "Go recipes", Topics: []entity.TopicWeight{{Topic: 1, Weight: 0.6}, {Topic: 0, Weight: 0.4}}},
+		{ID: "a3", Title: "Soup", Topics: []entity.TopicWeight{{Topic: 1, Weight: 1}}},
+	}}
+	grpcServer := NewServer(service)
+
+	tests := []struct {
+		name         string
+		request      *pb.GetArticlesByTopicRequest
+		expectedCode codes.Code
+	}{
+		{name: "nil request", request: nil, expectedCode: codes.InvalidArgument},
+		{name: "negative topic", request: &pb.GetArticlesByTopicRequest{Topic: -1, Limit: 10}, expectedCode: codes.InvalidArgument},
+		{name: "no limit", request: &pb.GetArticlesByTopicRequest{Topic: 0}, expectedCode: codes.InvalidArgument},
+		{name: "limit too large", request: &pb.GetArticlesByTopicRequest{Topic: 0, Limit: maxTopicArticles + 1}, expectedCode: codes.InvalidArgument},
+		{name: "valid request", request: &pb.GetArticlesByTopicRequest{Topic: 0, Limit: 10}, expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := grpcServer.GetArticlesByTopic(context.Background(), tt.request)
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("Expected code %v, got %v", tt.expectedCode, err)
+			}
+			if tt.expectedCode != codes.OK {
+				return
+			}
+
+			if len(response.Articles) != 2 || response.Articles[0].Id != "a1" || response.Articles[1].Id != "a2" {
+				t.Fatalf("Expected articles a1 and a2, got %v", response.Articles)
+			}
+			if second := response.Articles[1]; second.Weight != 0.4 || len(second.Topics) != 2 || second.Topics[0].Topic != 1 {
+				t.Errorf("Expected weight 0.4 of a mixture led by topic 1, got %v", second)
+			}
+		})
+	}
+}
+
+func TestServer_Topics_Disabled(t *testing.T) {
+	grpcServer := NewServer(app.NewArticleService(&MockArticleRepository{}))
+
+	if _, err := grpcServer.ListTopics(context.Background(), &pb.ListTopicsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected code %v, got %v", codes.Unimplemented, err)
+	}
+	_, err := grpcServer.GetArticlesByTopic(context.Background(), &pb.GetArticlesByTopicRequest{Limit: 10})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected code %v, got %v", codes.Unimplemented, err)
+	}
+}
+
 func TestServer_GetTopTags(t *testing.T) {
 	tests := []struct {
 		name         string
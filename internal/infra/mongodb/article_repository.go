@@ -6,12 +6,20 @@ import (
 	"time"
 
 	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/utils"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// documentFrequencySuffix names the per-term document-frequency collection
+// kept next to the articles collection.
+const documentFrequencySuffix = "_document_frequencies"
+
 type ArticleRepository struct {
-	collection *mongo.Collection
+	collection   *mongo.Collection
+	dfCollection *mongo.Collection
 }
 
 func NewArticleRepository(client *mongo.Client, dbName, collectionName string) *ArticleRepository {
@@ -28,12 +36,46 @@ func NewArticleRepository(client *mongo.Client, dbName, collectionName string) *
 	}
 
 	return &ArticleRepository{
-		collection: coll,
+		collection:   coll,
+		dfCollection: db.Collection(collectionName + documentFrequencySuffix),
 	}
 }
 
+// SaveArticle inserts article, giving it a new ID unless it has one. Once
+// the article is stored a failure to update the document frequencies is only
+// logged, so the caller does not retry and insert the article twice; the
+// frequencies are statistics and tolerate a missed article.
 func (r *ArticleRepository) SaveArticle(ctx context.Context, article *entity.Article) error {
+	if article.ID == "" {
+		article.ID = primitive.NewObjectID().Hex()
+	}
 	_, err := r.collection.InsertOne(ctx, article)
+	if err != nil {
+		return err
+	}
+
+	text := utils.PlainText(article.ContentType, article.Title) + "\n\n" + utils.PlainText(article.ContentType, article.Body)
+	if err := r.incrementDocumentFrequencies(ctx, utils.UniqueTerms(text)); err != nil {
+		log.Printf("failed to update document frequencies of article %s: %v\n", article.ID, err)
+	}
+	return nil
+}
+
+// incrementDocumentFrequencies adds one to the document frequency of each term.
+func (r *ArticleRepository) incrementDocumentFrequencies(ctx context.Context, terms []string) error {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(terms))
+	for _, term := range terms {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: term}}).
+			SetUpdate(bson.D{{Key: "$inc", Value: bson.D{{Key: "df", Value: 1}}}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.dfCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
 	return err
 }
 
@@ -78,3 +120,53 @@ func (r *ArticleRepository) GetTopTags(ctx context.Context, limit int) ([]entity
 	}
 	return topTags, nil
 }
+
+func (r *ArticleRepository) GetDocumentFrequencies(ctx context.Context) (*entity.DocumentFrequencies, error) {
+	total, err := r.collection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.dfCollection.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	df := &entity.DocumentFrequencies{
+		TotalDocuments: int(total),
+		Terms:          make(map[string]int),
+	}
+	for cursor.Next(ctx) {
+		var result struct {
+			Term string `bson:"_id"`
+			DF   int    `bson:"df"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		df.Terms[result.Term] = result.DF
+	}
+	return df, cursor.Err()
+}
+
+// ForEachCategorizedArticle calls fn with every article that has categories,
+// stopping at the first error.
+func (r *ArticleRepository) ForEachCategorizedArticle(ctx context.Context, fn func(article *entity.Article) error) error {
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "categories.0", Value: bson.D{{Key: "$exists", Value: true}}}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var article entity.Article
+		if err := cursor.Decode(&article); err != nil {
+			return err
+		}
+		if err := fn(&article); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
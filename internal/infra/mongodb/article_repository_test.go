@@ -51,6 +51,36 @@ func TestArticleRepository_Integration(t *testing.T) {
 
 	t.Logf("Retrieved %d tags from integration test", len(tags))
 
+	// Test document frequencies
+	df, err := repo.GetDocumentFrequencies(context.Background())
+	if err != nil {
+		t.Errorf("Failed to get document frequencies: %v", err)
+	} else if df.Terms["integration"] != 1 {
+		t.Errorf("Expected document frequency 1 for 'integration', got %d", df.Terms["integration"])
+	}
+
+	// Test reading the articles with categories
+	categorized := &entity.Article{
+		Title:      "Categorized Article",
+		Body:       "An article with a section",
+		Categories: []string{"technology"},
+		CreatedAt:  time.Now(),
+	}
+	if err := repo.SaveArticle(context.Background(), categorized); err != nil {
+		t.Errorf("Failed to save article: %v", err)
+	}
+	var titles []string
+	err = repo.ForEachCategorizedArticle(context.Background(), func(article *entity.Article) error {
+		titles = append(titles, article.Title)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Failed to read categorized articles: %v", err)
+	} else if len(titles) != 1 || titles[0] != "Categorized Article" {
+		t.Errorf("Expected only the categorized article, got %v", titles)
+	}
+
 	// Clean up
 	client.Database("test_db").Collection("test_collection").Drop(context.Background())
+	client.Database("test_db").Collection("test_collection" + documentFrequencySuffix).Drop(context.Background())
 }
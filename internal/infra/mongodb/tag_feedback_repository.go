@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// tagFeedbackSuffix names the editor-decision collection kept next to the
+// articles collection.
+const tagFeedbackSuffix = "_tag_feedback"
+
+type TagFeedbackRepository struct {
+	articles *mongo.Collection
+	feedback *mongo.Collection
+}
+
+// NewTagFeedbackRepository stores decisions on the tags of the articles
+// collection named articlesCollection.
+func NewTagFeedbackRepository(client *mongo.Client, dbName, articlesCollection string) *TagFeedbackRepository {
+	db := client.Database(dbName)
+	return &TagFeedbackRepository{
+		articles: db.Collection(articlesCollection),
+		feedback: db.Collection(articlesCollection + tagFeedbackSuffix),
+	}
+}
+
+func (r *TagFeedbackRepository) GetArticle(ctx context.Context, id string) (*entity.Article, error) {
+	var article entity.Article
+	err := r.articles.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&article)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, port.ErrArticleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+func (r *TagFeedbackRepository) UpdateArticleTags(ctx context.Context, id string, tags []string) error {
+	result, err := r.articles.UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: id}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "tags", Value: tags}}}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return port.ErrArticleNotFound
+	}
+	return nil
+}
+
+func (r *TagFeedbackRepository) SaveTagFeedback(ctx context.Context, feedback []entity.TagFeedback) error {
+	if len(feedback) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(feedback))
+	for _, f := range feedback {
+		documents = append(documents, f)
+	}
+	_, err := r.feedback.InsertMany(ctx, documents)
+	return err
+}
+
+// GetTagFeedbackStats counts the decisions on each tag of each source.
+func (r *TagFeedbackRepository) GetTagFeedbackStats(ctx context.Context) ([]entity.TagFeedbackStats, error) {
+	count := func(action string) bson.D {
+		return bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{"$action", action}}}, 1, 0,
+		}}}}}
+	}
+	pipeline := mongo.Pipeline{
+		//group by source and tag and count each action:
+		{{
+			Key: "$group", Value: bson.D{
+				{Key: "_id", Value: bson.D{{Key: "source", Value: "$source"}, {Key: "tag", Value: "$tag"}}},
+				{Key: "accepted", Value: count(entity.FeedbackAccept)},
+				{Key: "rejected", Value: count(entity.FeedbackReject)},
+				{Key: "added", Value: count(entity.FeedbackAdd)},
+			},
+		}},
+	}
+
+	cursor, err := r.feedback.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stats := []entity.TagFeedbackStats{}
+	for cursor.Next(ctx) {
+		var result struct {
+			ID struct {
+				Source string `bson:"source"`
+				Tag    string `bson:"tag"`
+			} `bson:"_id"`
+			Accepted int `bson:"accepted"`
+			Rejected int `bson:"rejected"`
+			Added    int `bson:"added"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		stats = append(stats, entity.TagFeedbackStats{
+			Source:   result.ID.Source,
+			Tag:      result.ID.Tag,
+			Accepted: result.Accepted,
+			Rejected: result.Rejected,
+			Added:    result.Added,
+		})
+	}
+	return stats, cursor.Err()
+}
@@ -0,0 +1,81 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/port"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Integration test helper (requires actual MongoDB instance)
+func TestTagFeedbackRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		t.Skipf("Skipping integration test: cannot connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	articles := NewArticleRepository(client, "test_db", "test_feedback_collection")
+	repo := NewTagFeedbackRepository(client, "test_db", "test_feedback_collection")
+	defer client.Database("test_db").Drop(context.Background())
+
+	article := &entity.Article{
+		Title:     "Feedback Test Article",
+		Body:      "An article editors review",
+		Source:    "blog",
+		Tags:      []string{"go", "article"},
+		CreatedAt: time.Now(),
+	}
+	if err := articles.SaveArticle(context.Background(), article); err != nil {
+		t.Fatalf("Failed to save article: %v", err)
+	}
+	if article.ID == "" {
+		t.Fatal("Expected the saved article to get an ID")
+	}
+
+	if err := repo.UpdateArticleTags(context.Background(), article.ID, []string{"go", "editing"}); err != nil {
+		t.Fatalf("Failed to update tags: %v", err)
+	}
+	stored, err := repo.GetArticle(context.Background(), article.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if !reflect.DeepEqual(stored.Tags, []string{"go", "editing"}) {
+		t.Errorf("Expected updated tags, got %v", stored.Tags)
+	}
+
+	if _, err := repo.GetArticle(context.Background(), "missing"); !errors.Is(err, port.ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+
+	err = repo.SaveTagFeedback(context.Background(), []entity.TagFeedback{
+		{ArticleID: article.ID, Source: "blog", Tag: "go", Action: entity.FeedbackAccept},
+		{ArticleID: article.ID, Source: "blog", Tag: "article", Action: entity.FeedbackReject},
+		{ArticleID: article.ID, Source: "blog", Tag: "go", Action: entity.FeedbackAccept},
+	})
+	if err != nil {
+		t.Fatalf("Failed to save feedback: %v", err)
+	}
+
+	stats, err := repo.GetTagFeedbackStats(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get feedback stats: %v", err)
+	}
+	counts := make(map[string]entity.TagFeedbackStats)
+	for _, s := range stats {
+		counts[s.Tag] = s
+	}
+	if counts["go"].Accepted != 2 || counts["article"].Rejected != 1 {
+		t.Errorf("Unexpected feedback stats: %v", stats)
+	}
+}
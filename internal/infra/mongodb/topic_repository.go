@@ -0,0 +1,170 @@
+package mongodb
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// topicsSuffix names the topic-model collection kept next to the articles
+// collection.
+const topicsSuffix = "_topics"
+
+// topicBatchSize bounds the article updates sent in one bulk write.
+const topicBatchSize = 1000
+
+type TopicRepository struct {
+	articles *mongo.Collection
+	topics   *mongo.Collection
+}
+
+// NewTopicRepository stores the topic model of the articles collection named
+// articlesCollection.
+func NewTopicRepository(client *mongo.Client, dbName, articlesCollection string) *TopicRepository {
+	db := client.Database(dbName)
+	articles := db.Collection(articlesCollection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := articles.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "topics.topic", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("failed to create index: %v\n", err)
+	}
+
+	return &TopicRepository{
+		articles: articles,
+		topics:   db.Collection(articlesCollection + topicsSuffix),
+	}
+}
+
+// ForEachArticle calls fn with every article, stopping at the first error.
+func (r *TopicRepository) ForEachArticle(ctx context.Context, fn func(article *entity.Article) error) error {
+	cursor, err := r.articles.Find(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var article entity.Article
+		if err := cursor.Decode(&article); err != nil {
+			return err
+		}
+		if err := fn(&article); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// SaveTopics replaces the stored topics with topics. Topics are replaced in
+// place, so readers never see an empty model.
+func (r *TopicRepository) SaveTopics(ctx context.Context, topics []entity.Topic) error {
+	models := make([]mongo.WriteModel, 0, len(topics)+1)
+	for _, topic := range topics {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: topic.ID}}).
+			SetReplacement(topic).
+			SetUpsert(true))
+	}
+	// drop the topics of a previous model with more topics
+	models = append(models, mongo.NewDeleteManyModel().
+		SetFilter(bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: len(topics)}}}}))
+
+	_, err := r.topics.BulkWrite(ctx, models)
+	return err
+}
+
+// SaveArticleTopics sets the topic mixtures of the articles, removing the
+// topics of articles without any.
+func (r *TopicRepository) SaveArticleTopics(ctx context.Context, mixtures map[string][]entity.TopicWeight) error {
+	models := make([]mongo.WriteModel, 0, min(len(mixtures), topicBatchSize))
+	for id, mixture := range mixtures {
+		update := bson.D{{Key: "$unset", Value: bson.D{{Key: "topics", Value: ""}}}}
+		if len(mixture) > 0 {
+			update = bson.D{{Key: "$set", Value: bson.D{{Key: "topics", Value: mixture}}}}
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: id}}).
+			SetUpdate(update))
+
+		if len(models) == topicBatchSize {
+			if _, err := r.articles.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+				return err
+			}
+			models = models[:0]
+		}
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+	_, err := r.articles.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// GetTopics returns the stored topics in the order of their IDs.
+func (r *TopicRepository) GetTopics(ctx context.Context) ([]entity.Topic, error) {
+	cursor, err := r.topics.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	topics := []entity.Topic{}
+	if err := cursor.All(ctx, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+// GetArticlesByTopic returns up to limit articles assigned to topic, those it
+// weighs most in first.
+func (r *TopicRepository) GetArticlesByTopic(ctx context.Context, topic int, limit int) ([]*entity.Article, error) {
+	pipeline := mongo.Pipeline{
+		//match the articles assigned to the topic:
+		{{Key: "$match", Value: bson.D{{Key: "topics.topic", Value: topic}}}},
+
+		//copy out the weight of the topic:
+		{{
+			Key: "$addFields", Value: bson.D{
+				{Key: "topic_weight", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{
+					bson.D{{Key: "$map", Value: bson.D{
+						{Key: "input", Value: bson.D{{Key: "$filter", Value: bson.D{
+							{Key: "input", Value: "$topics"},
+							{Key: "cond", Value: bson.D{{Key: "$eq", Value: bson.A{"$$this.topic", topic}}}},
+						}}}},
+						{Key: "in", Value: "$$this.weight"},
+					}}},
+					0,
+				}}}},
+			},
+		}},
+
+		//sort by weight in desc order:
+		{{Key: "$sort", Value: bson.D{{Key: "topic_weight", Value: -1}, {Key: "_id", Value: 1}}}},
+
+		//limit the results:
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.D{{Key: "topic_weight", Value: 0}}}},
+	}
+
+	cursor, err := r.articles.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	articles := []*entity.Article{}
+	if err := cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
@@ -0,0 +1,98 @@
+package mongodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Integration test helper (requires actual MongoDB instance)
+func TestTopicRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		t.Skipf("Skipping integration test: cannot connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	articles := NewArticleRepository(client, "test_db", "test_topics_collection")
+	repo := NewTopicRepository(client, "test_db", "test_topics_collection")
+	defer client.Database("test_db").Drop(context.Background())
+
+	ids := []string{}
+	for _, title := range []string{"Go", "Rust", "Cooking"} {
+		article := &entity.Article{Title: title, Body: title + " article", CreatedAt: time.Now()}
+		if err := articles.SaveArticle(context.Background(), article); err != nil {
+			t.Fatalf("Failed to save article: %v", err)
+		}
+		ids = append(ids, article.ID)
+	}
+
+	seen := 0
+	err = repo.ForEachArticle(context.Background(), func(article *entity.Article) error {
+		seen++
+		return nil
+	})
+	if err != nil || seen != 3 {
+		t.Fatalf("Expected 3 articles, got %d (%v)", seen, err)
+	}
+
+	// a previous model with more topics
+	old := []entity.Topic{{ID: 0}, {ID: 1}, {ID: 2}}
+	if err := repo.SaveTopics(context.Background(), old); err != nil {
+		t.Fatalf("Failed to save topics: %v", err)
+	}
+	topics := []entity.Topic{
+		{ID: 0, Weight: 0.6, Articles: 2, Words: []entity.TopicWord{{Word: "go", Probability: 0.3}}},
+		{ID: 1, Weight: 0.4, Articles: 2, Words: []entity.TopicWord{{Word: "cooking", Probability: 0.2}}},
+	}
+	if err := repo.SaveTopics(context.Background(), topics); err != nil {
+		t.Fatalf("Failed to save topics: %v", err)
+	}
+	stored, err := repo.GetTopics(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get topics: %v", err)
+	}
+	if !reflect.DeepEqual(stored, topics) {
+		t.Errorf("Expected %v, got %v", topics, stored)
+	}
+
+	err = repo.SaveArticleTopics(context.Background(), map[string][]entity.TopicWeight{
+		ids[0]: {{Topic: 0, Weight: 0.9}},
+		ids[1]: {{Topic: 0, Weight: 0.6}, {Topic: 1, Weight: 0.4}},
+		ids[2]: {{Topic: 1, Weight: 0.95}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to save article topics: %v", err)
+	}
+
+	byTopic, err := repo.GetArticlesByTopic(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to get articles by topic: %v", err)
+	}
+	if len(byTopic) != 2 || byTopic[0].Title != "Go" || byTopic[1].Title != "Rust" {
+		t.Fatalf("Expected Go then Rust, got %v", byTopic)
+	}
+	if !reflect.DeepEqual(byTopic[1].Topics, []entity.TopicWeight{{Topic: 0, Weight: 0.6}, {Topic: 1, Weight: 0.4}}) {
+		t.Errorf("Expected the whole mixture, got %v", byTopic[1].Topics)
+	}
+	if limited, _ := repo.GetArticlesByTopic(context.Background(), 1, 1); len(limited) != 1 || limited[0].Title != "Cooking" {
+		t.Errorf("Expected Cooking only, got %v", limited)
+	}
+
+	// retraining can leave an article without topics
+	if err := repo.SaveArticleTopics(context.Background(), map[string][]entity.TopicWeight{ids[2]: nil}); err != nil {
+		t.Fatalf("Failed to save article topics: %v", err)
+	}
+	if byTopic, _ := repo.GetArticlesByTopic(context.Background(), 1, 10); len(byTopic) != 1 || byTopic[0].Title != "Rust" {
+		t.Errorf("Expected Rust only, got %v", byTopic)
+	}
+}
@@ -0,0 +1,49 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// vocabularySuffix names the controlled-vocabulary collection kept next to
+// the articles collection.
+const vocabularySuffix = "_vocabulary"
+
+type VocabularyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewVocabularyRepository stores the vocabulary of the articles collection
+// named articlesCollection.
+func NewVocabularyRepository(client *mongo.Client, dbName, articlesCollection string) *VocabularyRepository {
+	return &VocabularyRepository{
+		collection: client.Database(dbName).Collection(articlesCollection + vocabularySuffix),
+	}
+}
+
+func (r *VocabularyRepository) GetVocabulary(ctx context.Context) ([]entity.VocabularyTerm, error) {
+	cursor, err := r.collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	terms := []entity.VocabularyTerm{}
+	if err := cursor.All(ctx, &terms); err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// SaveVocabularyTerm inserts term or replaces the aliases of an existing one.
+func (r *VocabularyRepository) SaveVocabularyTerm(ctx context.Context, term entity.VocabularyTerm) error {
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.D{{Key: "_id", Value: term.Tag}},
+		term,
+		options.Replace().SetUpsert(true))
+	return err
+}
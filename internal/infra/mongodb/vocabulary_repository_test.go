@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/SaeedMPro/article-tag-extractor/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Integration test helper (requires actual MongoDB instance)
+func TestVocabularyRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		t.Skipf("Skipping integration test: cannot connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	repo := NewVocabularyRepository(client, "test_db", "test_collection")
+	defer client.Database("test_db").Collection("test_collection" + vocabularySuffix).Drop(context.Background())
+
+	for _, term := range []entity.VocabularyTerm{
+		{Tag: "go", Aliases: []string{"golang"}},
+		{Tag: "go", Aliases: []string{"golang", "go-lang"}},
+		{Tag: "javascript", Aliases: []string{"js"}},
+	} {
+		if err := repo.SaveVocabularyTerm(context.Background(), term); err != nil {
+			t.Fatalf("Failed to save vocabulary term: %v", err)
+		}
+	}
+
+	terms, err := repo.GetVocabulary(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get vocabulary: %v", err)
+	}
+
+	expected := []entity.VocabularyTerm{
+		{Tag: "go", Aliases: []string{"golang", "go-lang"}},
+		{Tag: "javascript", Aliases: []string{"js"}},
+	}
+	if !reflect.DeepEqual(terms, expected) {
+		t.Errorf("Expected %v, got %v", expected, terms)
+	}
+}
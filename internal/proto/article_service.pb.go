@@ -23,10 +23,22 @@ const (
 
 // --- request & response
 type ProcessArticlesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Articles      []*Article             `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Articles []*Article             `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+	// name of a registered extractor such as "tfidf" or "rake"; empty selects the default
+	Extractor string `protobuf:"bytes,2,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	// number of tags saved on each article, at most 100; 0 selects the configured default
+	MaxTags int32 `protobuf:"varint,3,opt,name=max_tags,json=maxTags,proto3" json:"max_tags,omitempty"`
+	// drops tags scoring below this fraction of the top score, from 0 to 1; 0 selects the configured default
+	MinScore float64 `protobuf:"fixed64,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// drops tags occurring fewer times in the article; 0 selects the configured default
+	MinFrequency int32 `protobuf:"varint,5,opt,name=min_frequency,json=minFrequency,proto3" json:"min_frequency,omitempty"`
+	// stores the explanation of every tag on the article
+	Explain bool `protobuf:"varint,6,opt,name=explain,proto3" json:"explain,omitempty"`
+	// number of sentences in the summary of each article, at most 20; 0 selects the configured default
+	SummarySentences int32 `protobuf:"varint,7,opt,name=summary_sentences,json=summarySentences,proto3" json:"summary_sentences,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ProcessArticlesRequest) Reset() {
@@ -66,11 +78,60 @@ func (x *ProcessArticlesRequest) GetArticles() []*Article {
 	return nil
 }
 
+func (x *ProcessArticlesRequest) GetExtractor() string {
+	if x != nil {
+		return x.Extractor
+	}
+	return ""
+}
+
+func (x *ProcessArticlesRequest) GetMaxTags() int32 {
+	if x != nil {
+		return x.MaxTags
+	}
+	return 0
+}
+
+func (x *ProcessArticlesRequest) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *ProcessArticlesRequest) GetMinFrequency() int32 {
+	if x != nil {
+		return x.MinFrequency
+	}
+	return 0
+}
+
+func (x *ProcessArticlesRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+func (x *ProcessArticlesRequest) GetSummarySentences() int32 {
+	if x != nil {
+		return x.SummarySentences
+	}
+	return 0
+}
+
 type ProcessArticlesResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	TotalProcessed int32                  `protobuf:"varint,1,opt,name=total_processed,json=totalProcessed,proto3" json:"total_processed,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// extractor that tagged the articles and its version
+	Extractor        string `protobuf:"bytes,2,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	ExtractorVersion string `protobuf:"bytes,3,opt,name=extractor_version,json=extractorVersion,proto3" json:"extractor_version,omitempty"`
+	// IDs of the saved articles in request order; empty for articles that failed to save
+	ArticleIds []string `protobuf:"bytes,4,rep,name=article_ids,json=articleIds,proto3" json:"article_ids,omitempty"`
+	// summaries of the articles in request order
+	Summaries     []string `protobuf:"bytes,5,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProcessArticlesResponse) Reset() {
@@ -110,6 +171,34 @@ func (x *ProcessArticlesResponse) GetTotalProcessed() int32 {
 	return 0
 }
 
+func (x *ProcessArticlesResponse) GetExtractor() string {
+	if x != nil {
+		return x.Extractor
+	}
+	return ""
+}
+
+func (x *ProcessArticlesResponse) GetExtractorVersion() string {
+	if x != nil {
+		return x.ExtractorVersion
+	}
+	return ""
+}
+
+func (x *ProcessArticlesResponse) GetArticleIds() []string {
+	if x != nil {
+		return x.ArticleIds
+	}
+	return nil
+}
+
+func (x *ProcessArticlesResponse) GetSummaries() []string {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
 type GetTopTagsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -198,29 +287,32 @@ func (x *GetTopTagsResponse) GetTags() []*TagFrequency {
 	return nil
 }
 
-// --- data models
-type Article struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+type ExplainTagsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Article *Article               `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`
+	// extractor and limits as in ProcessArticlesRequest
+	Extractor     string  `protobuf:"bytes,2,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	MaxTags       int32   `protobuf:"varint,3,opt,name=max_tags,json=maxTags,proto3" json:"max_tags,omitempty"`
+	MinScore      float64 `protobuf:"fixed64,4,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	MinFrequency  int32   `protobuf:"varint,5,opt,name=min_frequency,json=minFrequency,proto3" json:"min_frequency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Article) Reset() {
-	*x = Article{}
+func (x *ExplainTagsRequest) Reset() {
+	*x = ExplainTagsRequest{}
 	mi := &file_internal_proto_article_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Article) String() string {
+func (x *ExplainTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Article) ProtoMessage() {}
+func (*ExplainTagsRequest) ProtoMessage() {}
 
-func (x *Article) ProtoReflect() protoreflect.Message {
+func (x *ExplainTagsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_article_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -232,47 +324,73 @@ func (x *Article) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Article.ProtoReflect.Descriptor instead.
-func (*Article) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExplainTagsRequest.ProtoReflect.Descriptor instead.
+func (*ExplainTagsRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Article) GetTitle() string {
+func (x *ExplainTagsRequest) GetArticle() *Article {
 	if x != nil {
-		return x.Title
+		return x.Article
 	}
-	return ""
+	return nil
 }
 
-func (x *Article) GetBody() string {
+func (x *ExplainTagsRequest) GetExtractor() string {
 	if x != nil {
-		return x.Body
+		return x.Extractor
 	}
 	return ""
 }
 
-type TagFrequency struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Frequency     int32                  `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+func (x *ExplainTagsRequest) GetMaxTags() int32 {
+	if x != nil {
+		return x.MaxTags
+	}
+	return 0
+}
+
+func (x *ExplainTagsRequest) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *ExplainTagsRequest) GetMinFrequency() int32 {
+	if x != nil {
+		return x.MinFrequency
+	}
+	return 0
+}
+
+type ExplainTagsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Extractor        string                 `protobuf:"bytes,1,opt,name=extractor,proto3" json:"extractor,omitempty"`
+	ExtractorVersion string                 `protobuf:"bytes,2,opt,name=extractor_version,json=extractorVersion,proto3" json:"extractor_version,omitempty"`
+	Language         string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	// in the order the tags are saved in
+	Tags []*TagExplanation `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// categories the classifier assigns, most probable first
+	Categories    []*CategoryScore `protobuf:"bytes,5,rep,name=categories,proto3" json:"categories,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TagFrequency) Reset() {
-	*x = TagFrequency{}
+func (x *ExplainTagsResponse) Reset() {
+	*x = ExplainTagsResponse{}
 	mi := &file_internal_proto_article_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TagFrequency) String() string {
+func (x *ExplainTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagFrequency) ProtoMessage() {}
+func (*ExplainTagsResponse) ProtoMessage() {}
 
-func (x *TagFrequency) ProtoReflect() protoreflect.Message {
+func (x *ExplainTagsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_article_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -284,48 +402,1256 @@ func (x *TagFrequency) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagFrequency.ProtoReflect.Descriptor instead.
-func (*TagFrequency) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExplainTagsResponse.ProtoReflect.Descriptor instead.
+func (*ExplainTagsResponse) Descriptor() ([]byte, []int) {
 	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *TagFrequency) GetTag() string {
+func (x *ExplainTagsResponse) GetExtractor() string {
 	if x != nil {
-		return x.Tag
+		return x.Extractor
 	}
 	return ""
 }
 
-func (x *TagFrequency) GetFrequency() int32 {
+func (x *ExplainTagsResponse) GetExtractorVersion() string {
 	if x != nil {
-		return x.Frequency
+		return x.ExtractorVersion
+	}
+	return ""
+}
+
+func (x *ExplainTagsResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ExplainTagsResponse) GetTags() []*TagExplanation {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ExplainTagsResponse) GetCategories() []*CategoryScore {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type SubmitTagFeedbackRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ArticleId string                 `protobuf:"bytes,1,opt,name=article_id,json=articleId,proto3" json:"article_id,omitempty"`
+	// tags the editor confirmed
+	Accept []string `protobuf:"bytes,2,rep,name=accept,proto3" json:"accept,omitempty"`
+	// tags the editor removed from the article
+	Reject []string `protobuf:"bytes,3,rep,name=reject,proto3" json:"reject,omitempty"`
+	// tags the editor added to the article
+	Add           []string `protobuf:"bytes,4,rep,name=add,proto3" json:"add,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTagFeedbackRequest) Reset() {
+	*x = SubmitTagFeedbackRequest{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTagFeedbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTagFeedbackRequest) ProtoMessage() {}
+
+func (x *SubmitTagFeedbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTagFeedbackRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTagFeedbackRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubmitTagFeedbackRequest) GetArticleId() string {
+	if x != nil {
+		return x.ArticleId
+	}
+	return ""
+}
+
+func (x *SubmitTagFeedbackRequest) GetAccept() []string {
+	if x != nil {
+		return x.Accept
+	}
+	return nil
+}
+
+func (x *SubmitTagFeedbackRequest) GetReject() []string {
+	if x != nil {
+		return x.Reject
+	}
+	return nil
+}
+
+func (x *SubmitTagFeedbackRequest) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+type SubmitTagFeedbackResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tags of the article after the decisions
+	Tags          []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTagFeedbackResponse) Reset() {
+	*x = SubmitTagFeedbackResponse{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTagFeedbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTagFeedbackResponse) ProtoMessage() {}
+
+func (x *SubmitTagFeedbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTagFeedbackResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTagFeedbackResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubmitTagFeedbackResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type SummarizeRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Article *Article               `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`
+	// number of sentences as in ProcessArticlesRequest
+	Sentences     int32 `protobuf:"varint,2,opt,name=sentences,proto3" json:"sentences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeRequest) Reset() {
+	*x = SummarizeRequest{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeRequest) ProtoMessage() {}
+
+func (x *SummarizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeRequest.ProtoReflect.Descriptor instead.
+func (*SummarizeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SummarizeRequest) GetArticle() *Article {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+func (x *SummarizeRequest) GetSentences() int32 {
+	if x != nil {
+		return x.Sentences
 	}
 	return 0
 }
 
-var File_internal_proto_article_service_proto protoreflect.FileDescriptor
+type SummarizeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key sentences joined in reading order
+	Summary       string             `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Sentences     []*SummarySentence `protobuf:"bytes,2,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	Language      string             `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_internal_proto_article_service_proto_rawDesc = "" +
-	"\n" +
-	"$internal/proto/article_service.proto\x12\aarticle\"F\n" +
-	"\x16ProcessArticlesRequest\x12,\n" +
-	"\barticles\x18\x01 \x03(\v2\x10.article.ArticleR\barticles\"B\n" +
-	"\x17ProcessArticlesResponse\x12'\n" +
-	"\x0ftotal_processed\x18\x01 \x01(\x05R\x0etotalProcessed\")\n" +
-	"\x11GetTopTagsRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\"?\n" +
-	"\x12GetTopTagsResponse\x12)\n" +
-	"\x04tags\x18\x01 \x03(\v2\x15.article.TagFrequencyR\x04tags\"3\n" +
-	"\aArticle\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
-	"\x04body\x18\x02 \x01(\tR\x04body\">\n" +
-	"\fTagFrequency\x12\x10\n" +
-	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1c\n" +
-	"\tfrequency\x18\x02 \x01(\x05R\tfrequency2\xad\x01\n" +
-	"\x0eArticleService\x12T\n" +
-	"\x0fProcessArticles\x12\x1f.article.ProcessArticlesRequest\x1a .article.ProcessArticlesResponse\x12E\n" +
+func (x *SummarizeResponse) Reset() {
+	*x = SummarizeResponse{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeResponse) ProtoMessage() {}
+
+func (x *SummarizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeResponse.ProtoReflect.Descriptor instead.
+func (*SummarizeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SummarizeResponse) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *SummarizeResponse) GetSentences() []*SummarySentence {
+	if x != nil {
+		return x.Sentences
+	}
+	return nil
+}
+
+func (x *SummarizeResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type ListTopicsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// number of top words of each topic, at most 100; 0 returns every stored word
+	Words         int32 `protobuf:"varint,1,opt,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicsRequest) Reset() {
+	*x = ListTopicsRequest{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsRequest) ProtoMessage() {}
+
+func (x *ListTopicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopicsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTopicsRequest) GetWords() int32 {
+	if x != nil {
+		return x.Words
+	}
+	return 0
+}
+
+type ListTopicsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty until the topics job has run
+	Topics        []*Topic `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicsResponse) Reset() {
+	*x = ListTopicsResponse{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsResponse) ProtoMessage() {}
+
+func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTopicsResponse) GetTopics() []*Topic {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type GetArticlesByTopicRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Topic int32                  `protobuf:"varint,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// at most 100
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArticlesByTopicRequest) Reset() {
+	*x = GetArticlesByTopicRequest{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArticlesByTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArticlesByTopicRequest) ProtoMessage() {}
+
+func (x *GetArticlesByTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArticlesByTopicRequest.ProtoReflect.Descriptor instead.
+func (*GetArticlesByTopicRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetArticlesByTopicRequest) GetTopic() int32 {
+	if x != nil {
+		return x.Topic
+	}
+	return 0
+}
+
+func (x *GetArticlesByTopicRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetArticlesByTopicResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Articles      []*TopicArticle        `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArticlesByTopicResponse) Reset() {
+	*x = GetArticlesByTopicResponse{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArticlesByTopicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArticlesByTopicResponse) ProtoMessage() {}
+
+func (x *GetArticlesByTopicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArticlesByTopicResponse.ProtoReflect.Descriptor instead.
+func (*GetArticlesByTopicResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetArticlesByTopicResponse) GetArticles() []*TopicArticle {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+// --- data models
+type Article struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Title string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Body  string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// selects per-tenant overrides such as stop-word lists
+	Tenant string `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// selects a tag policy; takes precedence over the tenant policy
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// format of title and body: "text/plain" (default), "text/html" or "text/markdown"
+	ContentType string `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// sections assigned by editors; articles with categories train the category classifier
+	Categories    []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Article) Reset() {
+	*x = Article{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Article) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Article) ProtoMessage() {}
+
+func (x *Article) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Article.ProtoReflect.Descriptor instead.
+func (*Article) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Article) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Article) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Article) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Article) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Article) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Article) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type TagFrequency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Frequency     int32                  `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagFrequency) Reset() {
+	*x = TagFrequency{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagFrequency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagFrequency) ProtoMessage() {}
+
+func (x *TagFrequency) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagFrequency.ProtoReflect.Descriptor instead.
+func (*TagFrequency) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TagFrequency) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagFrequency) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+// TagExplanation breaks down the score of a tag: frequency occurrences weigh
+// field_weight together, early body occurrences multiply that by
+// position_boost and tf-idf multiplies it by idf.
+type TagExplanation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Frequency     int32                  `protobuf:"varint,3,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Idf           float64                `protobuf:"fixed64,4,opt,name=idf,proto3" json:"idf,omitempty"`
+	FieldWeight   float64                `protobuf:"fixed64,5,opt,name=field_weight,json=fieldWeight,proto3" json:"field_weight,omitempty"`
+	PositionBoost float64                `protobuf:"fixed64,6,opt,name=position_boost,json=positionBoost,proto3" json:"position_boost,omitempty"`
+	Occurrences   []*TagOccurrence       `protobuf:"bytes,7,rep,name=occurrences,proto3" json:"occurrences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagExplanation) Reset() {
+	*x = TagExplanation{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagExplanation) ProtoMessage() {}
+
+func (x *TagExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagExplanation.ProtoReflect.Descriptor instead.
+func (*TagExplanation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TagExplanation) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagExplanation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TagExplanation) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *TagExplanation) GetIdf() float64 {
+	if x != nil {
+		return x.Idf
+	}
+	return 0
+}
+
+func (x *TagExplanation) GetFieldWeight() float64 {
+	if x != nil {
+		return x.FieldWeight
+	}
+	return 0
+}
+
+func (x *TagExplanation) GetPositionBoost() float64 {
+	if x != nil {
+		return x.PositionBoost
+	}
+	return 0
+}
+
+func (x *TagExplanation) GetOccurrences() []*TagOccurrence {
+	if x != nil {
+		return x.Occurrences
+	}
+	return nil
+}
+
+// TagOccurrence is an occurrence of a tag at the byte offsets [start, end) of
+// the plain text of a field: "title", "summary", "headings" or "body"
+type TagOccurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Start         int32                  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagOccurrence) Reset() {
+	*x = TagOccurrence{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagOccurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagOccurrence) ProtoMessage() {}
+
+func (x *TagOccurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagOccurrence.ProtoReflect.Descriptor instead.
+func (*TagOccurrence) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TagOccurrence) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TagOccurrence) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TagOccurrence) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type CategoryScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Probability   float64                `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryScore) Reset() {
+	*x = CategoryScore{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryScore) ProtoMessage() {}
+
+func (x *CategoryScore) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryScore.ProtoReflect.Descriptor instead.
+func (*CategoryScore) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CategoryScore) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryScore) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type SummarySentence struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Score float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// byte offsets in the plain text of the body
+	Start         int32 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32 `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarySentence) Reset() {
+	*x = SummarySentence{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarySentence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarySentence) ProtoMessage() {}
+
+func (x *SummarySentence) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarySentence.ProtoReflect.Descriptor instead.
+func (*SummarySentence) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SummarySentence) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SummarySentence) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SummarySentence) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *SummarySentence) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+// Topic is a topic of the topic model. weight is its share of the words of
+// the archive and articles the number of articles it was assigned to.
+type Topic struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Weight   float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Articles int32                  `protobuf:"varint,3,opt,name=articles,proto3" json:"articles,omitempty"`
+	// most probable words first
+	Words         []*TopicWord `protobuf:"bytes,4,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Topic) Reset() {
+	*x = Topic{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Topic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topic) ProtoMessage() {}
+
+func (x *Topic) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topic.ProtoReflect.Descriptor instead.
+func (*Topic) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Topic) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Topic) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Topic) GetArticles() int32 {
+	if x != nil {
+		return x.Articles
+	}
+	return 0
+}
+
+func (x *Topic) GetWords() []*TopicWord {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type TopicWord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Probability   float64                `protobuf:"fixed64,2,opt,name=probability,proto3" json:"probability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicWord) Reset() {
+	*x = TopicWord{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicWord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicWord) ProtoMessage() {}
+
+func (x *TopicWord) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicWord.ProtoReflect.Descriptor instead.
+func (*TopicWord) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TopicWord) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *TopicWord) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type TopicArticle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Tags  []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// weight of the requested topic in the article
+	Weight float64 `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	// topic mixture of the article, largest weight first
+	Topics        []*TopicWeight `protobuf:"bytes,5,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicArticle) Reset() {
+	*x = TopicArticle{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicArticle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicArticle) ProtoMessage() {}
+
+func (x *TopicArticle) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicArticle.ProtoReflect.Descriptor instead.
+func (*TopicArticle) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TopicArticle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TopicArticle) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TopicArticle) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TopicArticle) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *TopicArticle) GetTopics() []*TopicWeight {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type TopicWeight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         int32                  `protobuf:"varint,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicWeight) Reset() {
+	*x = TopicWeight{}
+	mi := &file_internal_proto_article_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicWeight) ProtoMessage() {}
+
+func (x *TopicWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_article_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicWeight.ProtoReflect.Descriptor instead.
+func (*TopicWeight) Descriptor() ([]byte, []int) {
+	return file_internal_proto_article_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TopicWeight) GetTopic() int32 {
+	if x != nil {
+		return x.Topic
+	}
+	return 0
+}
+
+func (x *TopicWeight) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+var File_internal_proto_article_service_proto protoreflect.FileDescriptor
+
+const file_internal_proto_article_service_proto_rawDesc = "" +
+	"\n" +
+	"$internal/proto/article_service.proto\x12\aarticle\"\x88\x02\n" +
+	"\x16ProcessArticlesRequest\x12,\n" +
+	"\barticles\x18\x01 \x03(\v2\x10.article.ArticleR\barticles\x12\x1c\n" +
+	"\textractor\x18\x02 \x01(\tR\textractor\x12\x19\n" +
+	"\bmax_tags\x18\x03 \x01(\x05R\amaxTags\x12\x1b\n" +
+	"\tmin_score\x18\x04 \x01(\x01R\bminScore\x12#\n" +
+	"\rmin_frequency\x18\x05 \x01(\x05R\fminFrequency\x12\x18\n" +
+	"\aexplain\x18\x06 \x01(\bR\aexplain\x12+\n" +
+	"\x11summary_sentences\x18\a \x01(\x05R\x10summarySentences\"\xcc\x01\n" +
+	"\x17ProcessArticlesResponse\x12'\n" +
+	"\x0ftotal_processed\x18\x01 \x01(\x05R\x0etotalProcessed\x12\x1c\n" +
+	"\textractor\x18\x02 \x01(\tR\textractor\x12+\n" +
+	"\x11extractor_version\x18\x03 \x01(\tR\x10extractorVersion\x12\x1f\n" +
+	"\varticle_ids\x18\x04 \x03(\tR\n" +
+	"articleIds\x12\x1c\n" +
+	"\tsummaries\x18\x05 \x03(\tR\tsummaries\")\n" +
+	"\x11GetTopTagsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"?\n" +
+	"\x12GetTopTagsResponse\x12)\n" +
+	"\x04tags\x18\x01 \x03(\v2\x15.article.TagFrequencyR\x04tags\"\xbb\x01\n" +
+	"\x12ExplainTagsRequest\x12*\n" +
+	"\aarticle\x18\x01 \x01(\v2\x10.article.ArticleR\aarticle\x12\x1c\n" +
+	"\textractor\x18\x02 \x01(\tR\textractor\x12\x19\n" +
+	"\bmax_tags\x18\x03 \x01(\x05R\amaxTags\x12\x1b\n" +
+	"\tmin_score\x18\x04 \x01(\x01R\bminScore\x12#\n" +
+	"\rmin_frequency\x18\x05 \x01(\x05R\fminFrequency\"\xe1\x01\n" +
+	"\x13ExplainTagsResponse\x12\x1c\n" +
+	"\textractor\x18\x01 \x01(\tR\textractor\x12+\n" +
+	"\x11extractor_version\x18\x02 \x01(\tR\x10extractorVersion\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12+\n" +
+	"\x04tags\x18\x04 \x03(\v2\x17.article.TagExplanationR\x04tags\x126\n" +
+	"\n" +
+	"categories\x18\x05 \x03(\v2\x16.article.CategoryScoreR\n" +
+	"categories\"{\n" +
+	"\x18SubmitTagFeedbackRequest\x12\x1d\n" +
+	"\n" +
+	"article_id\x18\x01 \x01(\tR\tarticleId\x12\x16\n" +
+	"\x06accept\x18\x02 \x03(\tR\x06accept\x12\x16\n" +
+	"\x06reject\x18\x03 \x03(\tR\x06reject\x12\x10\n" +
+	"\x03add\x18\x04 \x03(\tR\x03add\"/\n" +
+	"\x19SubmitTagFeedbackResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"\\\n" +
+	"\x10SummarizeRequest\x12*\n" +
+	"\aarticle\x18\x01 \x01(\v2\x10.article.ArticleR\aarticle\x12\x1c\n" +
+	"\tsentences\x18\x02 \x01(\x05R\tsentences\"\x81\x01\n" +
+	"\x11SummarizeResponse\x12\x18\n" +
+	"\asummary\x18\x01 \x01(\tR\asummary\x126\n" +
+	"\tsentences\x18\x02 \x03(\v2\x18.article.SummarySentenceR\tsentences\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\")\n" +
+	"\x11ListTopicsRequest\x12\x14\n" +
+	"\x05words\x18\x01 \x01(\x05R\x05words\"<\n" +
+	"\x12ListTopicsResponse\x12&\n" +
+	"\x06topics\x18\x01 \x03(\v2\x0e.article.TopicR\x06topics\"G\n" +
+	"\x19GetArticlesByTopicRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\x05R\x05topic\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"O\n" +
+	"\x1aGetArticlesByTopicResponse\x121\n" +
+	"\barticles\x18\x01 \x03(\v2\x15.article.TopicArticleR\barticles\"\xa6\x01\n" +
+	"\aArticle\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x06 \x03(\tR\n" +
+	"categories\">\n" +
+	"\fTagFrequency\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1c\n" +
+	"\tfrequency\x18\x02 \x01(\x05R\tfrequency\"\xec\x01\n" +
+	"\x0eTagExplanation\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1c\n" +
+	"\tfrequency\x18\x03 \x01(\x05R\tfrequency\x12\x10\n" +
+	"\x03idf\x18\x04 \x01(\x01R\x03idf\x12!\n" +
+	"\ffield_weight\x18\x05 \x01(\x01R\vfieldWeight\x12%\n" +
+	"\x0eposition_boost\x18\x06 \x01(\x01R\rpositionBoost\x128\n" +
+	"\voccurrences\x18\a \x03(\v2\x16.article.TagOccurrenceR\voccurrences\"M\n" +
+	"\rTagOccurrence\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x05R\x03end\"M\n" +
+	"\rCategoryScore\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12 \n" +
+	"\vprobability\x18\x02 \x01(\x01R\vprobability\"c\n" +
+	"\x0fSummarySentence\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x05R\x03end\"u\n" +
+	"\x05Topic\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\x12\x1a\n" +
+	"\barticles\x18\x03 \x01(\x05R\barticles\x12(\n" +
+	"\x05words\x18\x04 \x03(\v2\x12.article.TopicWordR\x05words\"A\n" +
+	"\tTopicWord\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12 \n" +
+	"\vprobability\x18\x02 \x01(\x01R\vprobability\"\x8e\x01\n" +
+	"\fTopicArticle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\x12,\n" +
+	"\x06topics\x18\x05 \x03(\v2\x14.article.TopicWeightR\x06topics\";\n" +
+	"\vTopicWeight\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\x05R\x05topic\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight2\xbd\x04\n" +
+	"\x0eArticleService\x12T\n" +
+	"\x0fProcessArticles\x12\x1f.article.ProcessArticlesRequest\x1a .article.ProcessArticlesResponse\x12E\n" +
+	"\n" +
+	"GetTopTags\x12\x1a.article.GetTopTagsRequest\x1a\x1b.article.GetTopTagsResponse\x12H\n" +
+	"\vExplainTags\x12\x1b.article.ExplainTagsRequest\x1a\x1c.article.ExplainTagsResponse\x12Z\n" +
+	"\x11SubmitTagFeedback\x12!.article.SubmitTagFeedbackRequest\x1a\".article.SubmitTagFeedbackResponse\x12B\n" +
+	"\tSummarize\x12\x19.article.SummarizeRequest\x1a\x1a.article.SummarizeResponse\x12E\n" +
 	"\n" +
-	"GetTopTags\x12\x1a.article.GetTopTagsRequest\x1a\x1b.article.GetTopTagsResponseB\fZ\n" +
+	"ListTopics\x12\x1a.article.ListTopicsRequest\x1a\x1b.article.ListTopicsResponse\x12]\n" +
+	"\x12GetArticlesByTopic\x12\".article.GetArticlesByTopicRequest\x1a#.article.GetArticlesByTopicResponseB\fZ\n" +
 	"./;articleb\x06proto3"
 
 var (
@@ -340,27 +1666,65 @@ func file_internal_proto_article_service_proto_rawDescGZIP() []byte {
 	return file_internal_proto_article_service_proto_rawDescData
 }
 
-var file_internal_proto_article_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_internal_proto_article_service_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_internal_proto_article_service_proto_goTypes = []any{
-	(*ProcessArticlesRequest)(nil),  // 0: article.ProcessArticlesRequest
-	(*ProcessArticlesResponse)(nil), // 1: article.ProcessArticlesResponse
-	(*GetTopTagsRequest)(nil),       // 2: article.GetTopTagsRequest
-	(*GetTopTagsResponse)(nil),      // 3: article.GetTopTagsResponse
-	(*Article)(nil),                 // 4: article.Article
-	(*TagFrequency)(nil),            // 5: article.TagFrequency
+	(*ProcessArticlesRequest)(nil),     // 0: article.ProcessArticlesRequest
+	(*ProcessArticlesResponse)(nil),    // 1: article.ProcessArticlesResponse
+	(*GetTopTagsRequest)(nil),          // 2: article.GetTopTagsRequest
+	(*GetTopTagsResponse)(nil),         // 3: article.GetTopTagsResponse
+	(*ExplainTagsRequest)(nil),         // 4: article.ExplainTagsRequest
+	(*ExplainTagsResponse)(nil),        // 5: article.ExplainTagsResponse
+	(*SubmitTagFeedbackRequest)(nil),   // 6: article.SubmitTagFeedbackRequest
+	(*SubmitTagFeedbackResponse)(nil),  // 7: article.SubmitTagFeedbackResponse
+	(*SummarizeRequest)(nil),           // 8: article.SummarizeRequest
+	(*SummarizeResponse)(nil),          // 9: article.SummarizeResponse
+	(*ListTopicsRequest)(nil),          // 10: article.ListTopicsRequest
+	(*ListTopicsResponse)(nil),         // 11: article.ListTopicsResponse
+	(*GetArticlesByTopicRequest)(nil),  // 12: article.GetArticlesByTopicRequest
+	(*GetArticlesByTopicResponse)(nil), // 13: article.GetArticlesByTopicResponse
+	(*Article)(nil),                    // 14: article.Article
+	(*TagFrequency)(nil),               // 15: article.TagFrequency
+	(*TagExplanation)(nil),             // 16: article.TagExplanation
+	(*TagOccurrence)(nil),              // 17: article.TagOccurrence
+	(*CategoryScore)(nil),              // 18: article.CategoryScore
+	(*SummarySentence)(nil),            // 19: article.SummarySentence
+	(*Topic)(nil),                      // 20: article.Topic
+	(*TopicWord)(nil),                  // 21: article.TopicWord
+	(*TopicArticle)(nil),               // 22: article.TopicArticle
+	(*TopicWeight)(nil),                // 23: article.TopicWeight
 }
 var file_internal_proto_article_service_proto_depIdxs = []int32{
-	4, // 0: article.ProcessArticlesRequest.articles:type_name -> article.Article
-	5, // 1: article.GetTopTagsResponse.tags:type_name -> article.TagFrequency
-	0, // 2: article.ArticleService.ProcessArticles:input_type -> article.ProcessArticlesRequest
-	2, // 3: article.ArticleService.GetTopTags:input_type -> article.GetTopTagsRequest
-	1, // 4: article.ArticleService.ProcessArticles:output_type -> article.ProcessArticlesResponse
-	3, // 5: article.ArticleService.GetTopTags:output_type -> article.GetTopTagsResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	14, // 0: article.ProcessArticlesRequest.articles:type_name -> article.Article
+	15, // 1: article.GetTopTagsResponse.tags:type_name -> article.TagFrequency
+	14, // 2: article.ExplainTagsRequest.article:type_name -> article.Article
+	16, // 3: article.ExplainTagsResponse.tags:type_name -> article.TagExplanation
+	18, // 4: article.ExplainTagsResponse.categories:type_name -> article.CategoryScore
+	14, // 5: article.SummarizeRequest.article:type_name -> article.Article
+	19, // 6: article.SummarizeResponse.sentences:type_name -> article.SummarySentence
+	20, // 7: article.ListTopicsResponse.topics:type_name -> article.Topic
+	22, // 8: article.GetArticlesByTopicResponse.articles:type_name -> article.TopicArticle
+	17, // 9: article.TagExplanation.occurrences:type_name -> article.TagOccurrence
+	21, // 10: article.Topic.words:type_name -> article.TopicWord
+	23, // 11: article.TopicArticle.topics:type_name -> article.TopicWeight
+	0,  // 12: article.ArticleService.ProcessArticles:input_type -> article.ProcessArticlesRequest
+	2,  // 13: article.ArticleService.GetTopTags:input_type -> article.GetTopTagsRequest
+	4,  // 14: article.ArticleService.ExplainTags:input_type -> article.ExplainTagsRequest
+	6,  // 15: article.ArticleService.SubmitTagFeedback:input_type -> article.SubmitTagFeedbackRequest
+	8,  // 16: article.ArticleService.Summarize:input_type -> article.SummarizeRequest
+	10, // 17: article.ArticleService.ListTopics:input_type -> article.ListTopicsRequest
+	12, // 18: article.ArticleService.GetArticlesByTopic:input_type -> article.GetArticlesByTopicRequest
+	1,  // 19: article.ArticleService.ProcessArticles:output_type -> article.ProcessArticlesResponse
+	3,  // 20: article.ArticleService.GetTopTags:output_type -> article.GetTopTagsResponse
+	5,  // 21: article.ArticleService.ExplainTags:output_type -> article.ExplainTagsResponse
+	7,  // 22: article.ArticleService.SubmitTagFeedback:output_type -> article.SubmitTagFeedbackResponse
+	9,  // 23: article.ArticleService.Summarize:output_type -> article.SummarizeResponse
+	11, // 24: article.ArticleService.ListTopics:output_type -> article.ListTopicsResponse
+	13, // 25: article.ArticleService.GetArticlesByTopic:output_type -> article.GetArticlesByTopicResponse
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_article_service_proto_init() }
@@ -374,7 +1738,7 @@ func file_internal_proto_article_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_article_service_proto_rawDesc), len(file_internal_proto_article_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ArticleService_ProcessArticles_FullMethodName = "/article.ArticleService/ProcessArticles"
-	ArticleService_GetTopTags_FullMethodName      = "/article.ArticleService/GetTopTags"
+	ArticleService_ProcessArticles_FullMethodName    = "/article.ArticleService/ProcessArticles"
+	ArticleService_GetTopTags_FullMethodName         = "/article.ArticleService/GetTopTags"
+	ArticleService_ExplainTags_FullMethodName        = "/article.ArticleService/ExplainTags"
+	ArticleService_SubmitTagFeedback_FullMethodName  = "/article.ArticleService/SubmitTagFeedback"
+	ArticleService_Summarize_FullMethodName          = "/article.ArticleService/Summarize"
+	ArticleService_ListTopics_FullMethodName         = "/article.ArticleService/ListTopics"
+	ArticleService_GetArticlesByTopic_FullMethodName = "/article.ArticleService/GetArticlesByTopic"
 )
 
 // ArticleServiceClient is the client API for ArticleService service.
@@ -33,6 +38,16 @@ type ArticleServiceClient interface {
 	ProcessArticles(ctx context.Context, in *ProcessArticlesRequest, opts ...grpc.CallOption) (*ProcessArticlesResponse, error)
 	// extract the top N frequent tags
 	GetTopTags(ctx context.Context, in *GetTopTagsRequest, opts ...grpc.CallOption) (*GetTopTagsResponse, error)
+	// tags an article without saving it and explains every tag
+	ExplainTags(ctx context.Context, in *ExplainTagsRequest, opts ...grpc.CallOption) (*ExplainTagsResponse, error)
+	// records editor decisions on the tags of a saved article
+	SubmitTagFeedback(ctx context.Context, in *SubmitTagFeedbackRequest, opts ...grpc.CallOption) (*SubmitTagFeedbackResponse, error)
+	// picks the key sentences of an article without saving it
+	Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (*SummarizeResponse, error)
+	// lists the topics of the topic model with their top words
+	ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error)
+	// returns the articles assigned to a topic, those it weighs most in first
+	GetArticlesByTopic(ctx context.Context, in *GetArticlesByTopicRequest, opts ...grpc.CallOption) (*GetArticlesByTopicResponse, error)
 }
 
 type articleServiceClient struct {
@@ -63,6 +78,56 @@ func (c *articleServiceClient) GetTopTags(ctx context.Context, in *GetTopTagsReq
 	return out, nil
 }
 
+func (c *articleServiceClient) ExplainTags(ctx context.Context, in *ExplainTagsRequest, opts ...grpc.CallOption) (*ExplainTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainTagsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ExplainTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) SubmitTagFeedback(ctx context.Context, in *SubmitTagFeedbackRequest, opts ...grpc.CallOption) (*SubmitTagFeedbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitTagFeedbackResponse)
+	err := c.cc.Invoke(ctx, ArticleService_SubmitTagFeedback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (*SummarizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SummarizeResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Summarize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopicsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListTopics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetArticlesByTopic(ctx context.Context, in *GetArticlesByTopicRequest, opts ...grpc.CallOption) (*GetArticlesByTopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArticlesByTopicResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetArticlesByTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ArticleServiceServer is the server API for ArticleService service.
 // All implementations must embed UnimplementedArticleServiceServer
 // for forward compatibility.
@@ -73,6 +138,16 @@ type ArticleServiceServer interface {
 	ProcessArticles(context.Context, *ProcessArticlesRequest) (*ProcessArticlesResponse, error)
 	// extract the top N frequent tags
 	GetTopTags(context.Context, *GetTopTagsRequest) (*GetTopTagsResponse, error)
+	// tags an article without saving it and explains every tag
+	ExplainTags(context.Context, *ExplainTagsRequest) (*ExplainTagsResponse, error)
+	// records editor decisions on the tags of a saved article
+	SubmitTagFeedback(context.Context, *SubmitTagFeedbackRequest) (*SubmitTagFeedbackResponse, error)
+	// picks the key sentences of an article without saving it
+	Summarize(context.Context, *SummarizeRequest) (*SummarizeResponse, error)
+	// lists the topics of the topic model with their top words
+	ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error)
+	// returns the articles assigned to a topic, those it weighs most in first
+	GetArticlesByTopic(context.Context, *GetArticlesByTopicRequest) (*GetArticlesByTopicResponse, error)
 	mustEmbedUnimplementedArticleServiceServer()
 }
 
@@ -89,6 +164,21 @@ func (UnimplementedArticleServiceServer) ProcessArticles(context.Context, *Proce
 func (UnimplementedArticleServiceServer) GetTopTags(context.Context, *GetTopTagsRequest) (*GetTopTagsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTopTags not implemented")
 }
+func (UnimplementedArticleServiceServer) ExplainTags(context.Context, *ExplainTagsRequest) (*ExplainTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainTags not implemented")
+}
+func (UnimplementedArticleServiceServer) SubmitTagFeedback(context.Context, *SubmitTagFeedbackRequest) (*SubmitTagFeedbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTagFeedback not implemented")
+}
+func (UnimplementedArticleServiceServer) Summarize(context.Context, *SummarizeRequest) (*SummarizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Summarize not implemented")
+}
+func (UnimplementedArticleServiceServer) ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopics not implemented")
+}
+func (UnimplementedArticleServiceServer) GetArticlesByTopic(context.Context, *GetArticlesByTopicRequest) (*GetArticlesByTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArticlesByTopic not implemented")
+}
 func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
 func (UnimplementedArticleServiceServer) testEmbeddedByValue()                        {}
 
@@ -146,6 +236,96 @@ func _ArticleService_GetTopTags_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_ExplainTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ExplainTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ExplainTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ExplainTags(ctx, req.(*ExplainTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_SubmitTagFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTagFeedbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).SubmitTagFeedback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_SubmitTagFeedback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).SubmitTagFeedback(ctx, req.(*SubmitTagFeedbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Summarize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Summarize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Summarize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Summarize(ctx, req.(*SummarizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListTopics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListTopics(ctx, req.(*ListTopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetArticlesByTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArticlesByTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetArticlesByTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetArticlesByTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetArticlesByTopic(ctx, req.(*GetArticlesByTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +341,26 @@ var ArticleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTopTags",
 			Handler:    _ArticleService_GetTopTags_Handler,
 		},
+		{
+			MethodName: "ExplainTags",
+			Handler:    _ArticleService_ExplainTags_Handler,
+		},
+		{
+			MethodName: "SubmitTagFeedback",
+			Handler:    _ArticleService_SubmitTagFeedback_Handler,
+		},
+		{
+			MethodName: "Summarize",
+			Handler:    _ArticleService_Summarize_Handler,
+		},
+		{
+			MethodName: "ListTopics",
+			Handler:    _ArticleService_ListTopics_Handler,
+		},
+		{
+			MethodName: "GetArticlesByTopic",
+			Handler:    _ArticleService_GetArticlesByTopic_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/article_service.proto",
@@ -0,0 +1,110 @@
+package utils
+
+import "sort"
+
+// Matcher finds every occurrence of a set of phrases in tokenized text in a
+// single pass, using an Aho-Corasick automaton whose alphabet is words rather
+// than characters. Matches therefore always start and end at word
+// boundaries.
+type Matcher struct {
+	nodes   []matcherNode
+	lengths []int
+}
+
+type matcherNode struct {
+	next map[string]int
+	fail int
+	// patterns ending at this node, including those reached by fail links
+	output []int
+}
+
+// Match is an occurrence of pattern Pattern covering tokens [Start, End).
+type Match struct {
+	Pattern int
+	Start   int
+	End     int
+}
+
+// NewMatcher builds a matcher for patterns, each a sequence of normalized
+// words. Matches report a pattern by its index. Empty patterns never match.
+func NewMatcher(patterns [][]string) *Matcher {
+	m := &Matcher{nodes: []matcherNode{{next: map[string]int{}}}, lengths: make([]int, len(patterns))}
+	for i, pattern := range patterns {
+		m.lengths[i] = len(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+		node := 0
+		for _, word := range pattern {
+			child, ok := m.nodes[node].next[word]
+			if !ok {
+				child = len(m.nodes)
+				m.nodes = append(m.nodes, matcherNode{next: map[string]int{}})
+				m.nodes[node].next[word] = child
+			}
+			node = child
+		}
+		m.nodes[node].output = append(m.nodes[node].output, i)
+	}
+
+	// breadth-first, so fail links always point at nodes already done
+	queue := []int{}
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for word, child := range m.nodes[node].next {
+			fail := m.nodes[node].fail
+			for fail > 0 && m.nodes[fail].next[word] == 0 {
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[word]; ok && next != child {
+				m.nodes[child].fail = next
+			}
+			m.nodes[child].output = append(m.nodes[child].output, m.nodes[m.nodes[child].fail].output...)
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// FindAll returns every match in tokens, overlapping ones included, ordered
+// by the token they end at.
+func (m *Matcher) FindAll(tokens []string) []Match {
+	matches := []Match{}
+	node := 0
+	for i, token := range tokens {
+		for node > 0 && m.nodes[node].next[token] == 0 {
+			node = m.nodes[node].fail
+		}
+		node = m.nodes[node].next[token]
+		for _, pattern := range m.nodes[node].output {
+			matches = append(matches, Match{Pattern: pattern, Start: i + 1 - m.lengths[pattern], End: i + 1})
+		}
+	}
+	return matches
+}
+
+// FindLongest returns the leftmost-longest matches in tokens that do not
+// overlap, in text order, so "new york city" wins over "new york".
+func (m *Matcher) FindLongest(tokens []string) []Match {
+	matches := m.FindAll(tokens)
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
+
+	longest := []Match{}
+	end := 0
+	for _, match := range matches {
+		if match.Start >= end {
+			longest = append(longest, match)
+			end = match.End
+		}
+	}
+	return longest
+}
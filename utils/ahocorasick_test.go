@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatcher_FindAll(t *testing.T) {
+	matcher := NewMatcher([][]string{
+		{"new", "york"},
+		{"new", "york", "city"},
+		{"york", "city", "hall"},
+		{"hall"},
+		{},
+	})
+
+	tokens := Tokenize("from New York City Hall to new york")
+	expected := []Match{
+		{Pattern: 0, Start: 1, End: 3},
+		{Pattern: 1, Start: 1, End: 4},
+		{Pattern: 2, Start: 2, End: 5},
+		{Pattern: 3, Start: 4, End: 5},
+		{Pattern: 0, Start: 6, End: 8},
+	}
+	if result := matcher.FindAll(tokens); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestMatcher_FindLongest(t *testing.T) {
+	matcher := NewMatcher([][]string{
+		{"new", "york"},
+		{"new", "york", "city"},
+		{"york", "city", "hall"},
+		{"hall"},
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []Match
+	}{
+		{
+			name:     "Longest match wins",
+			input:    "new york city hall",
+			expected: []Match{{Pattern: 1, Start: 0, End: 3}, {Pattern: 3, Start: 3, End: 4}},
+		},
+		{
+			name:     "Fail links find later matches",
+			input:    "new new york",
+			expected: []Match{{Pattern: 0, Start: 1, End: 3}},
+		},
+		{
+			name:     "Words only match whole",
+			input:    "newyork yorkcity halls",
+			expected: []Match{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := matcher.FindLongest(Tokenize(tt.input)); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
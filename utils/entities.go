@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EntityMention is a named entity found in a text: a run of capitalized words
+// such as "New York Times" or an acronym such as "NASA". Text is the mention
+// as written, with whitespace collapsed; Start and End are byte offsets.
+type EntityMention struct {
+	Text    string
+	Start   int
+	End     int
+	Acronym bool
+}
+
+// entityConnectors may join capitalized words inside a name, as in "Bank of
+// America" or "Vincent van Gogh".
+var entityConnectors = map[string]bool{
+	"of": true, "de": true, "da": true, "di": true, "du": true, "la": true, "le": true,
+	"van": true, "von": true, "der": true, "al": true, "bin": true, "ibn": true,
+}
+
+// entityWord classifies a word as written.
+type entityWord int
+
+const (
+	plainWord entityWord = iota
+	capitalizedWord
+	acronymWord
+	connectorWord
+)
+
+func classifyEntityWord(word string) entityWord {
+	if entityConnectors[word] {
+		return connectorWord
+	}
+	if utf8.RuneCountInString(word) < 2 {
+		return plainWord
+	}
+
+	upper := 0
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case !unicode.IsDigit(r):
+			first, _ := utf8.DecodeRuneInString(word)
+			if unicode.IsUpper(first) {
+				return capitalizedWord
+			}
+			return plainWord
+		}
+	}
+	if upper >= 2 {
+		return acronymWord
+	}
+	return plainWord
+}
+
+// DetectEntities finds the named entities of text by its capitalization, so it
+// must be called before the text is lowercased. A capitalized word at the start
+// of a sentence only counts when the same word is also capitalized elsewhere
+// in a sentence. A name starting a sentence loses its first word when that is
+// a stop word such as "The" or a word also written in lowercase. Scripts
+// without case yield no entities, and neither does German, which capitalizes
+// every noun.
+func DetectEntities(text string, stopWords *StopWords, tenant, language string) []EntityMention {
+	mentions := []EntityMention{}
+	if language == "de" {
+		return mentions
+	}
+	tokens := (*Tokenizer)(nil).Tokens(text)
+
+	lowercase := make(map[string]bool)
+	for _, token := range tokens {
+		if text[token.Start:token.End] == token.Text {
+			lowercase[token.Text] = true
+		}
+	}
+
+	// single words capitalized at the start of a sentence, and the words seen
+	// capitalized anywhere else
+	var initial []EntityMention
+	capitalized := make(map[string]bool)
+
+	emit := func(run []Token, sentenceStart bool) {
+		// connectors only join words
+		for len(run) > 0 && classifyEntityWord(text[run[len(run)-1].Start:run[len(run)-1].End]) == connectorWord {
+			run = run[:len(run)-1]
+		}
+		if len(run) > 0 && sentenceStart && (stopWords.Contains(tenant, language, run[0].Text) || lowercase[run[0].Text]) {
+			run = run[1:]
+			sentenceStart = false
+		}
+		for len(run) > 0 && classifyEntityWord(text[run[0].Start:run[0].End]) == connectorWord {
+			run = run[1:]
+		}
+		if len(run) == 0 {
+			return
+		}
+
+		start, end := run[0].Start, run[len(run)-1].End
+		m := EntityMention{
+			Text:    strings.Join(strings.Fields(text[start:end]), " "),
+			Start:   start,
+			End:     end,
+			Acronym: len(run) == 1 && classifyEntityWord(text[start:end]) == acronymWord,
+		}
+		switch {
+		case len(run) > 1 || m.Acronym:
+			mentions = append(mentions, m)
+		case stopWords.Contains(tenant, language, run[0].Text):
+		case sentenceStart:
+			initial = append(initial, m)
+		default:
+			capitalized[run[0].Text] = true
+			mentions = append(mentions, m)
+		}
+	}
+
+	var run []Token
+	runStart := false
+	sentenceStart := true
+	end := 0
+	for _, token := range tokens {
+		gap := text[end:token.Start]
+		end = token.End
+		if len(run) > 0 && !isSpaceGap(gap) {
+			emit(run, runStart)
+			run = nil
+		}
+		if isSentenceBreak(gap) {
+			sentenceStart = true
+		}
+
+		switch classifyEntityWord(text[token.Start:token.End]) {
+		case capitalizedWord, acronymWord:
+			if len(run) == 0 {
+				runStart = sentenceStart
+			}
+			run = append(run, token)
+		case connectorWord:
+			if len(run) > 0 {
+				run = append(run, token)
+			}
+		default:
+			if len(run) > 0 {
+				emit(run, runStart)
+				run = nil
+			}
+		}
+		sentenceStart = false
+	}
+	if len(run) > 0 {
+		emit(run, runStart)
+	}
+
+	for _, m := range initial {
+		if capitalized[strings.ToLower(NormalizePersian(m.Text))] {
+			mentions = append(mentions, m)
+		}
+	}
+	sort.SliceStable(mentions, func(i, j int) bool {
+		return mentions[i].Start < mentions[j].Start
+	})
+	return mentions
+}
+
+// isSpaceGap reports whether the text between two words is plain spacing
+// within a line.
+func isSpaceGap(gap string) bool {
+	for _, r := range gap {
+		if r == '\n' || !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSentenceBreak reports whether the text between two words ends a sentence.
+// Line breaks count, since headings and list items rarely end in punctuation.
+func isSentenceBreak(gap string) bool {
+	return strings.ContainsAny(gap, ".!?؟\n")
+}
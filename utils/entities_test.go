@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectEntities(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		language string
+		expected []string
+	}{
+		{
+			name:     "Multi-word names",
+			input:    "Reports in The New York Times say the Bank of America will grow.",
+			language: "en",
+			expected: []string{"The New York Times", "Bank of America"},
+		},
+		{
+			name:     "Single capitalized words",
+			input:    "Later that day Saeed met the mayor in Paris.",
+			language: "en",
+			expected: []string{"Saeed", "Paris"},
+		},
+		{
+			name:     "Leading stop word is dropped",
+			input:    "The European Commission met on Monday.",
+			language: "en",
+			expected: []string{"European Commission", "Monday"},
+		},
+		{
+			name:     "Sentence-initial words need a second capitalized mention",
+			input:    "Apple ships phones. Analysts expect Apple to grow. Analysts disagree.",
+			language: "en",
+			expected: []string{"Apple", "Apple"},
+		},
+		{
+			name:     "Sentence-initial word written in lowercase elsewhere",
+			input:    "Yesterday NASA launched a probe it had announced yesterday.",
+			language: "en",
+			expected: []string{"NASA"},
+		},
+		{
+			name:     "Acronyms",
+			input:    "the deal between the EU and the US was signed",
+			language: "en",
+			expected: []string{"EU", "US"},
+		},
+		{
+			name:     "Punctuation and line breaks end names",
+			input:    "we met Alice, Bob\nCarol and Dave",
+			language: "en",
+			expected: []string{"Alice", "Bob", "Dave"},
+		},
+		{
+			name:     "Caseless scripts",
+			input:    "سعید به تهران رفت",
+			language: "fa",
+			expected: []string{},
+		},
+		{
+			name:     "German capitalizes every noun",
+			input:    "Die Regierung hat einen Plan.",
+			language: "de",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := []string{}
+			for _, m := range DetectEntities(tt.input, DefaultStopWords(), "", tt.language) {
+				result = append(result, m.Text)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectEntities_Mentions(t *testing.T) {
+	text := "talks between NASA and\tSpace  Force"
+	expected := []EntityMention{
+		{Text: "NASA", Start: 14, End: 18, Acronym: true},
+		{Text: "Space Force", Start: 23, End: 35},
+	}
+
+	if result := DetectEntities(text, DefaultStopWords(), "", "en"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
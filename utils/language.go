@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"embed"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// stopWordProfileWeight is how often each stop word is counted when it is
+	// added to a language profile, reflecting how common stop words are in
+	// running text compared to the sample texts.
+	stopWordProfileWeight = 5
+
+	// trigramVocabulary is the assumed number of distinct trigrams used for
+	// add-one smoothing.
+	trigramVocabulary = 20000
+
+	// minDetectionMargin is the smallest difference in average log likelihood
+	// per trigram between the two best languages that is trusted. Short or
+	// ambiguous texts fall back to the default language of their script.
+	minDetectionMargin = 0.1
+)
+
+//go:embed languages/profiles/*.txt
+var profileFiles embed.FS
+
+// languageScripts restricts each language to the script it is written in, so
+// only languages sharing the dominant script of a text are compared.
+var languageScripts = map[string]*unicode.RangeTable{
+	"en": unicode.Latin,
+	"de": unicode.Latin,
+	"fr": unicode.Latin,
+	"es": unicode.Latin,
+	"fa": unicode.Arabic,
+	"ar": unicode.Arabic,
+}
+
+// scriptDefaults names the language assumed for a script when detection is
+// not confident.
+var scriptDefaults = map[*unicode.RangeTable]string{
+	unicode.Latin:  DefaultLanguage,
+	unicode.Arabic: "fa",
+}
+
+// languageProfile holds the log probability of each character trigram in a
+// language.
+type languageProfile struct {
+	language string
+	logProbs map[string]float64
+	unseen   float64
+}
+
+// languageProfiles are trigram profiles built from embedded sample texts and
+// stop-word lists, sorted by language code.
+var languageProfiles = loadLanguageProfiles()
+
+func loadLanguageProfiles() []languageProfile {
+	entries, err := profileFiles.ReadDir("languages/profiles")
+	if err != nil {
+		panic(err)
+	}
+
+	profiles := make([]languageProfile, 0, len(entries))
+	for _, entry := range entries {
+		data, err := profileFiles.ReadFile(path.Join("languages/profiles", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		language := strings.TrimSuffix(entry.Name(), ".txt")
+
+		counts := countTrigrams(string(data))
+		for word := range embeddedStopWords[language] {
+			for trigram, n := range countTrigrams(word) {
+				counts[trigram] += n * stopWordProfileWeight
+			}
+		}
+
+		total := 0
+		for _, n := range counts {
+			total += n
+		}
+		denominator := float64(total + trigramVocabulary)
+
+		logProbs := make(map[string]float64, len(counts))
+		for trigram, n := range counts {
+			logProbs[trigram] = math.Log(float64(n+1) / denominator)
+		}
+		profiles = append(profiles, languageProfile{
+			language: language,
+			logProbs: logProbs,
+			unseen:   math.Log(1 / denominator),
+		})
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].language < profiles[j].language
+	})
+	return profiles
+}
+
+// DetectLanguage returns the ISO 639-1 code of the language text is most
+// likely written in, or an empty string if no known language uses its script.
+// Languages of the dominant script are ranked by the likelihood of the
+// character trigrams of text under their profiles.
+func DetectLanguage(text string) string {
+	script := dominantScript(text)
+	if script == nil {
+		return ""
+	}
+
+	trigrams := countTrigrams(text)
+	total := 0
+	for _, n := range trigrams {
+		total += n
+	}
+	if total == 0 {
+		return scriptDefaults[script]
+	}
+
+	best, second := "", math.Inf(-1)
+	bestScore := math.Inf(-1)
+	for _, profile := range languageProfiles {
+		if languageScripts[profile.language] != script {
+			continue
+		}
+
+		score := 0.0
+		for trigram, n := range trigrams {
+			logProb, ok := profile.logProbs[trigram]
+			if !ok {
+				logProb = profile.unseen
+			}
+			score += float64(n) * logProb
+		}
+		score /= float64(total)
+
+		if score > bestScore {
+			best, bestScore, second = profile.language, score, bestScore
+		} else if score > second {
+			second = score
+		}
+	}
+
+	if bestScore-second < minDetectionMargin {
+		return scriptDefaults[script]
+	}
+	return best
+}
+
+// dominantScript returns the script used by most letters of text among the
+// scripts of known languages.
+func dominantScript(text string) *unicode.RangeTable {
+	counts := make(map[*unicode.RangeTable]int)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for _, script := range scriptDefaultOrder {
+			if unicode.Is(script, r) {
+				counts[script]++
+				break
+			}
+		}
+	}
+
+	var dominant *unicode.RangeTable
+	for _, script := range scriptDefaultOrder {
+		if counts[script] > 0 && (dominant == nil || counts[script] > counts[dominant]) {
+			dominant = script
+		}
+	}
+	return dominant
+}
+
+// scriptDefaultOrder fixes the order scripts are checked in, so ties between
+// scripts resolve the same way every time.
+var scriptDefaultOrder = []*unicode.RangeTable{unicode.Latin, unicode.Arabic}
+
+// countTrigrams counts the character trigrams of text. Words are padded with
+// '_' so that prefixes and suffixes form trigrams of their own.
+func countTrigrams(text string) map[string]int {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		runes := []rune("_" + word + "_")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "English",
+			input:    "Go is a programming language developed by Google for building scalable applications.",
+			expected: "en",
+		},
+		{
+			name:     "German",
+			input:    "Die Größe der Städte wächst, während die Dörfer auf dem Land schrumpfen.",
+			expected: "de",
+		},
+		{
+			name:     "French",
+			input:    "Le chat mange du poisson dans la cuisine pendant que les enfants jouent.",
+			expected: "fr",
+		},
+		{
+			name:     "Spanish",
+			input:    "El gato come pescado en la cocina mientras los niños juegan en el jardín.",
+			expected: "es",
+		},
+		{
+			name:     "Persian",
+			input:    "یادگیری ماشین شاخه‌ای از هوش مصنوعی است که به رایانه‌ها امکان یادگیری می‌دهد.",
+			expected: "fa",
+		},
+		{
+			name:     "Arabic",
+			input:    "الذكاء الاصطناعي هو فرع من علوم الحاسوب يهتم بصنع آلات ذكية.",
+			expected: "ar",
+		},
+		{
+			name:     "Short Latin text falls back to default",
+			input:    "Kubernetes Operators",
+			expected: DefaultLanguage,
+		},
+		{
+			name:     "Unknown script",
+			input:    "Привет мир",
+			expected: "",
+		},
+		{
+			name:     "No letters",
+			input:    "1234 !!!",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DetectLanguage(tt.input); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLanguageProfilesMatchStopWordLists(t *testing.T) {
+	for _, profile := range languageProfiles {
+		if _, ok := embeddedStopWords[profile.language]; !ok {
+			t.Errorf("Language '%s' has a profile but no stop-word list", profile.language)
+		}
+		if _, ok := languageScripts[profile.language]; !ok {
+			t.Errorf("Language '%s' has a profile but no script", profile.language)
+		}
+	}
+	if len(languageProfiles) != len(languageScripts) {
+		t.Errorf("Expected %d profiles, got %d", len(languageScripts), len(languageProfiles))
+	}
+}
@@ -0,0 +1,284 @@
+package utils
+
+import (
+	"mime"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Content types understood by ExtractText.
+const (
+	ContentTypePlain    = "text/plain"
+	ContentTypeHTML     = "text/html"
+	ContentTypeMarkdown = "text/markdown"
+)
+
+// paragraphBreak separates blocks of extracted text. SplitFragments never
+// joins words across it into one phrase.
+const paragraphBreak = "\n\n"
+
+// ParseContentType maps a MIME type or a short name such as "html" or "md" to
+// one of the known content types. An empty value is plain text.
+func ParseContentType(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if mediaType, _, err := mime.ParseMediaType(value); err == nil {
+		value = mediaType
+	}
+
+	switch value {
+	case "", "plain", "text", ContentTypePlain:
+		return ContentTypePlain, true
+	case "html", ContentTypeHTML, "application/xhtml+xml":
+		return ContentTypeHTML, true
+	case "markdown", "md", ContentTypeMarkdown, "text/x-markdown":
+		return ContentTypeMarkdown, true
+	}
+	return "", false
+}
+
+// Markup is the readable text of a marked-up document. Heading text is kept
+// apart from the rest so it can be weighted as its own field.
+type Markup struct {
+	Text     string
+	Headings []string
+}
+
+// ExtractText strips the markup of content. Entities are decoded and script,
+// style and code blocks are dropped. Unknown content types are treated as
+// plain text.
+func ExtractText(contentType, content string) Markup {
+	contentType, _ = ParseContentType(contentType)
+	switch contentType {
+	case ContentTypeHTML:
+		return extractHTML(content)
+	case ContentTypeMarkdown:
+		return extractMarkdown(content)
+	}
+	return Markup{Text: content}
+}
+
+// PlainText returns the headings and text of content as one string.
+func PlainText(contentType, content string) string {
+	m := ExtractText(contentType, content)
+	return strings.Join(append(m.Headings, m.Text), paragraphBreak)
+}
+
+// skippedElements hold no readable text. Code blocks are in pre elements;
+// inline code is kept, as in Markdown.
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Pre:      true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Head:     true,
+	atom.Svg:      true,
+	atom.Math:     true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+}
+
+var headingElements = map[atom.Atom]bool{
+	atom.H1: true, atom.H2: true, atom.H3: true,
+	atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// blockElements start a new paragraph, so their text never runs into the
+// text around them.
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true,
+	atom.Br: true, atom.Dd: true, atom.Details: true, atom.Div: true,
+	atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true,
+	atom.Footer: true, atom.Header: true, atom.Hr: true, atom.Li: true,
+	atom.Main: true, atom.Nav: true, atom.Ol: true, atom.P: true,
+	atom.Section: true, atom.Summary: true, atom.Table: true, atom.Td: true,
+	atom.Th: true, atom.Tr: true, atom.Ul: true,
+}
+
+func extractHTML(content string) Markup {
+	root, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		// the parser only fails on reader errors
+		return Markup{Text: content}
+	}
+
+	var text strings.Builder
+	var headings []string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.CommentNode:
+			return
+		case n.Type == html.ElementNode && skippedElements[n.DataAtom]:
+			return
+		case n.Type == html.ElementNode && headingElements[n.DataAtom]:
+			var heading strings.Builder
+			collectText(n, &heading)
+			if h := strings.Join(strings.Fields(heading.String()), " "); h != "" {
+				headings = append(headings, h)
+			}
+			text.WriteString(paragraphBreak)
+			return
+		case n.Type == html.TextNode:
+			text.WriteString(n.Data)
+			return
+		}
+
+		block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+		if block {
+			text.WriteString(paragraphBreak)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			text.WriteString(paragraphBreak)
+		}
+	}
+	walk(root)
+
+	return Markup{Text: normalizeParagraphs(text.String()), Headings: headings}
+}
+
+// collectText appends the readable text below n.
+func collectText(n *html.Node, b *strings.Builder) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case c.Type == html.TextNode:
+			b.WriteString(c.Data)
+		case c.Type == html.ElementNode && !skippedElements[c.DataAtom]:
+			b.WriteString(" ")
+			collectText(c, b)
+			b.WriteString(" ")
+		}
+	}
+}
+
+var (
+	markdownFence      = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	markdownATXHeading = regexp.MustCompile(`^\s{0,3}#{1,6}(\s+|$)`)
+	markdownClosingATX = regexp.MustCompile(`\s+#+\s*$`)
+	markdownSetext     = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	markdownRule       = regexp.MustCompile(`^\s{0,3}([-*_]\s*){3,}$`)
+	markdownReference  = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*\S+`)
+	markdownListMarker = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+`)
+	markdownQuote      = regexp.MustCompile(`^\s*(>\s?)+`)
+	markdownImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink       = regexp.MustCompile(`\[([^\]]*)\](\([^)]*\)|\[[^\]]*\])`)
+	markdownAutolink   = regexp.MustCompile(`<(https?|ftp|mailto):[^>]*>`)
+	markdownEmphasis   = regexp.MustCompile(`(\*+|~~|\b_+|_+\b)`)
+)
+
+func extractMarkdown(content string) Markup {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var text []string
+	var headings []string
+	fence := ""
+	previousBlank := true
+	previousQuote := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		// fenced code blocks are dropped up to the closing fence
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				text = append(text, "")
+			}
+			continue
+		}
+		if m := markdownFence.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			text = append(text, "")
+			continue
+		}
+
+		blank := strings.TrimSpace(line) == ""
+		quote := markdownQuote.MatchString(line)
+		switch {
+		case blank:
+			text = append(text, "")
+		case previousBlank && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")):
+			// indented code block; stays "blank" so following code lines
+			// are dropped too
+			blank = true
+		case markdownATXHeading.MatchString(line):
+			heading := markdownClosingATX.ReplaceAllString(markdownATXHeading.ReplaceAllString(line, ""), "")
+			headings = appendHeading(headings, heading)
+			text = append(text, "")
+		case i+1 < len(lines) && markdownSetext.MatchString(lines[i+1]) && !markdownRule.MatchString(line) && !markdownListMarker.MatchString(line):
+			headings = appendHeading(headings, line)
+			text = append(text, "")
+			i++
+		case markdownRule.MatchString(line), markdownReference.MatchString(line):
+			text = append(text, "")
+		default:
+			line = markdownQuote.ReplaceAllString(line, "")
+			if markdownListMarker.MatchString(line) || quote != previousQuote {
+				// list items and quotes are separate blocks
+				text = append(text, "")
+				line = markdownListMarker.ReplaceAllString(line, "")
+			}
+			text = append(text, stripInlineMarkdown(line))
+		}
+		previousBlank = blank
+		previousQuote = quote
+	}
+
+	// inline HTML and entities are handled by the HTML extractor
+	body := extractHTML(strings.Join(text, "\n"))
+	return Markup{Text: body.Text, Headings: append(headings, body.Headings...)}
+}
+
+func appendHeading(headings []string, heading string) []string {
+	heading = strings.Join(strings.Fields(stripInlineMarkdown(heading)), " ")
+	heading = html.UnescapeString(heading)
+	if heading == "" {
+		return headings
+	}
+	return append(headings, heading)
+}
+
+func stripInlineMarkdown(line string) string {
+	line = markdownImage.ReplaceAllString(line, "$1")
+	line = markdownLink.ReplaceAllString(line, "$1")
+	line = markdownAutolink.ReplaceAllString(line, "")
+	line = markdownEmphasis.ReplaceAllString(line, "")
+	return strings.ReplaceAll(line, "`", "")
+}
+
+// normalizeParagraphs trims the lines of text and collapses runs of blank
+// lines into a single paragraph break.
+func normalizeParagraphs(text string) string {
+	paragraphs := []string{}
+	for _, paragraph := range splitParagraphs(text) {
+		if p := strings.Join(strings.Fields(paragraph), " "); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return strings.Join(paragraphs, paragraphBreak)
+}
+
+// splitParagraphs splits text at blank lines.
+func splitParagraphs(text string) []string {
+	paragraphs := []string{}
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, "\n"))
+	}
+	return paragraphs
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseContentType(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{value: "", expected: ContentTypePlain, ok: true},
+		{value: "text/plain", expected: ContentTypePlain, ok: true},
+		{value: "HTML", expected: ContentTypeHTML, ok: true},
+		{value: "text/html; charset=utf-8", expected: ContentTypeHTML, ok: true},
+		{value: "md", expected: ContentTypeMarkdown, ok: true},
+		{value: "text/markdown", expected: ContentTypeMarkdown, ok: true},
+		{value: "application/pdf", expected: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			contentType, ok := ParseContentType(tt.value)
+			if contentType != tt.expected || ok != tt.ok {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, contentType, ok)
+			}
+		})
+	}
+}
+
+func TestExtractText_HTML(t *testing.T) {
+	content := `<html><head><title>Ignored</title><style>.div { color: red }</style></head>
+<body>
+  <h1>Rust &amp; Go</h1>
+  <div class="lead"><p>Systems&nbsp;languages are <a href="https://example.com">popular</a>.</p></div>
+  <script>var href = "nbsp";</script>
+  <pre><code>func main() {}</code></pre>
+  <ul><li>memory safety</li><li>fast builds</li></ul>
+  <!-- a comment -->
+  <h2>Adoption <code>today</code></h2>
+  <p>Teams adopt both with <code>cargo</code> and <code>go mod</code>.</p>
+</body></html>`
+
+	m := ExtractText(ContentTypeHTML, content)
+
+	if !reflect.DeepEqual(m.Headings, []string{"Rust & Go", "Adoption today"}) {
+		t.Errorf("Expected headings [Rust & Go, Adoption today], got %q", m.Headings)
+	}
+	expected := "Systems languages are popular.\n\nmemory safety\n\nfast builds\n\nTeams adopt both with cargo and go mod."
+	if m.Text != expected {
+		t.Errorf("Expected text %q, got %q", expected, m.Text)
+	}
+	for _, word := range []string{"div", "href", "nbsp", "func", "Ignored", "comment"} {
+		if strings.Contains(m.Text, word) {
+			t.Errorf("Expected '%s' to be stripped from %q", word, m.Text)
+		}
+	}
+}
+
+func TestExtractText_Markdown(t *testing.T) {
+	content := "# Release *notes*\n" +
+		"\n" +
+		"The **new** [scheduler](https://example.com/s) ships with `cron` support &amp; more.\n" +
+		"\n" +
+		"```go\n" +
+		"func main() { fmt.Println(\"hidden\") }\n" +
+		"```\n" +
+		"\n" +
+		"    indented code is hidden too\n" +
+		"\n" +
+		"Upgrade guide\n" +
+		"-------------\n" +
+		"\n" +
+		"- back up data\n" +
+		"- run migrations\n" +
+		"> quoted snake_case text\n" +
+		"\n" +
+		"![diagram](img.png) [ref]: https://example.com\n" +
+		"[ref]: https://example.com\n"
+
+	m := ExtractText("markdown", content)
+
+	if !reflect.DeepEqual(m.Headings, []string{"Release notes", "Upgrade guide"}) {
+		t.Errorf("Expected headings [Release notes Upgrade guide], got %q", m.Headings)
+	}
+	expected := "The new scheduler ships with cron support & more.\n\n" +
+		"back up data\n\nrun migrations\n\nquoted snake_case text\n\ndiagram [ref]: https://example.com"
+	if m.Text != expected {
+		t.Errorf("Expected text %q, got %q", expected, m.Text)
+	}
+}
+
+func TestExtractText_Plain(t *testing.T) {
+	content := "<p>kept as is</p> &amp;"
+	if m := ExtractText("", content); m.Text != content || m.Headings != nil {
+		t.Errorf("Expected plain text to be unchanged, got %+v", m)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	if text := PlainText(ContentTypeHTML, "<h1>Title</h1><p>Body</p>"); text != "Title\n\nBody" {
+		t.Errorf("Expected %q, got %q", "Title\n\nBody", text)
+	}
+}
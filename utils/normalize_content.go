@@ -1,41 +1,50 @@
 package utils
 
-import (
-	"regexp"
-	"strings"
-)
-
-var stopWords = map[string]bool{
-	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
-	"be": true, "by": true, "for": true, "from": true, "he": true,
-	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true,
-	"that": true, "the": true, "to": true, "was": true, "will": true, "with": true,
-	"this": true, "these": true, "they": true, "them": true, "their": true,
-	"there": true, "then": true, "than": true, "or": true, "but": true, "not": true,
-	"have": true, "had": true, "has": true, "having": true, "been": true,
-	"being": true, "do": true, "does": true, "did": true, "doing": true,
-	"can": true, "could": true, "should": true, "would": true, "may": true,
-	"might": true, "must": true, "shall": true, "i": true, "you": true, "we": true,
-	"us": true, "our": true, "my": true, "me": true, "him": true, "her": true,
-	"his": true, "she": true, "all": true, "any": true, "both": true, "each": true,
-	"few": true, "more": true, "most": true, "other": true, "some": true,
-	"such": true, "no": true, "nor": true, "so": true, "too": true, "very": true,
-	"those": true, "your": true, "were": true, "over": true,
+// Tokenize splits the input text into lowercase words. A word is a run of
+// Unicode letters, numbers and combining marks; everything else separates
+// words. Diacritics and tatweel are removed, digits become ASCII and a
+// zero-width non-joiner inside a word is kept as part of it. Letters are kept
+// as written; NormalizeLetters rewrites them for a language.
+func Tokenize(text string) []string {
+	return (*Tokenizer)(nil).Tokenize(text)
 }
 
-func IsStopWord(word string) bool {
-	return stopWords[word]
+// SplitFragments splits text at punctuation and blank lines into fragments
+// that can hold a phrase. Each fragment is returned as its lowercase words.
+func SplitFragments(text string) [][]string {
+	fragments := [][]string{}
+	for _, fragment := range (*Tokenizer)(nil).Fragments(text) {
+		words := make([]string, len(fragment))
+		for i, token := range fragment {
+			words[i] = token.Text
+		}
+		fragments = append(fragments, words)
+	}
+	return fragments
 }
 
-// Tokenize splits the input text into lowercase words, removing punctuation.
-func Tokenize(text string) []string {
-	words := []string{}
-
-	reg := regexp.MustCompile(`[^a-zA-Z\s]`)
-	content := reg.ReplaceAllString(text, " ")
+// UniqueTerms returns the distinct non-stop-word tokens of text in order of
+// first appearance, followed by the distinct terms matched by the built-in
+// protected patterns. It defines the terms counted for document frequencies.
+func UniqueTerms(text string) []string {
+	language := DetectLanguage(text)
+	stopWords := CurrentStopWords()
 
-	for _, word := range strings.Fields(content) {
-		words = append(words, strings.ToLower(word))
+	terms := []string{}
+	seen := make(map[string]bool)
+	for _, token := range Tokenize(text) {
+		token = NormalizeLetters(language, token)
+		if stopWords.Contains("", language, token) || seen[token] {
+			continue
+		}
+		seen[token] = true
+		terms = append(terms, token)
+	}
+	for _, token := range NormalizeTokens(language, builtinTokenizer.Tokens(text)) {
+		if token.Protected && !seen[token.Text] {
+			seen[token.Text] = true
+			terms = append(terms, token.Text)
+		}
 	}
-	return words
+	return terms
 }
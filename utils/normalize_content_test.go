@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -23,7 +24,7 @@ func TestTokenize(t *testing.T) {
 		{
 			name:     "Text with numbers and symbols",
 			input:    "Go 1.21 is awesome! @golang #programming",
-			expected: []string{"go", "is", "awesome", "golang", "programming"},
+			expected: []string{"go", "1", "21", "is", "awesome", "golang", "programming"},
 		},
 		{
 			name:     "Empty string",
@@ -43,7 +44,7 @@ func TestTokenize(t *testing.T) {
 		{
 			name:     "Text with special characters",
 			input:    "API v2.0 & REST endpoints",
-			expected: []string{"api", "v", "rest", "endpoints"},
+			expected: []string{"api", "v2", "0", "rest", "endpoints"},
 		},
 		{
 			name:     "Text with multiple spaces",
@@ -58,7 +59,32 @@ func TestTokenize(t *testing.T) {
 		{
 			name:     "Unicode text",
 			input:    "Café naïve résumé",
-			expected: []string{"caf", "na", "ve", "r", "sum"}, // Unicode characters are stripped by regex
+			expected: []string{"café", "naïve", "résumé"},
+		},
+		{
+			name:     "Decomposed accents",
+			input:    "Cafe\u0301 Gru\u0308n",
+			expected: []string{"cafe\u0301", "gru\u0308n"},
+		},
+		{
+			name:     "German umlauts",
+			input:    "Über Straßen und Flüsse",
+			expected: []string{"über", "straßen", "und", "flüsse"},
+		},
+		{
+			name:     "Persian text",
+			input:    "كتاب‌هاي علمي، جديد!",
+			expected: []string{"كتاب\u200cهاي", "علمي", "جديد"},
+		},
+		{
+			name:     "Arabic text with diacritics",
+			input:    "اللُّغَةُ العَرَبِيَّةُ",
+			expected: []string{"اللغة", "العربية"},
+		},
+		{
+			name:     "Dangling ZWNJ",
+			input:    "\u200cکتاب\u200c کتاب",
+			expected: []string{"کتاب", "کتاب"},
 		},
 	}
 
@@ -144,3 +170,100 @@ func TestTokenizeAndStopWordIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestUniqueTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Repeated words",
+			input:    "Data pipelines move data between data stores",
+			expected: []string{"data", "pipelines", "move", "between", "stores"},
+		},
+		{
+			name:     "Protected terms are added",
+			input:    "Compilers for C++ and COVID-19 dashboards",
+			expected: []string{"compilers", "c", "covid", "19", "dashboards", "c++", "covid-19"},
+		},
+		{
+			name:     "Persian letters in Persian text",
+			input:    "كتاب‌هاي علمي و كتاب‌هاي تاريخي در اين كتابخانه است",
+			expected: []string{"کتاب\u200cهای", "علمی", "تاریخی", "کتابخانه"},
+		},
+		{
+			name:     "Arabic letters in Arabic text",
+			input:    "المدرسة العربية الكبيرة في المدينة",
+			expected: []string{"المدرسة", "العربية", "الكبيرة", "المدينة"},
+		},
+		{
+			name:     "Only stopwords",
+			input:    "The and or but not",
+			expected: []string{},
+		},
+		{
+			name:     "Empty string",
+			input:    "",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UniqueTerms(tt.input)
+
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, result)
+			}
+			for i, term := range result {
+				if term != tt.expected[i] {
+					t.Errorf("Expected term %d to be '%s', got '%s'", i, tt.expected[i], term)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitFragments(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected [][]string
+	}{
+		{
+			name:     "Sentence punctuation",
+			input:    "Machine learning works. Deep learning, too!",
+			expected: [][]string{{"machine", "learning", "works"}, {"deep", "learning"}, {"too"}},
+		},
+		{
+			name:     "Digits and symbols",
+			input:    "Go 1.21 adds generics",
+			expected: [][]string{{"go", "1"}, {"21", "adds", "generics"}},
+		},
+		{
+			name:     "Persian punctuation",
+			input:    "یادگیری ماشین، شبکه عصبی",
+			expected: [][]string{{"یادگیری", "ماشین"}, {"شبکه", "عصبی"}},
+		},
+		{
+			name:     "Blank lines separate paragraphs",
+			input:    "memory safety\n\n  \nfast builds\nand tooling",
+			expected: [][]string{{"memory", "safety"}, {"fast", "builds", "and", "tooling"}},
+		},
+		{
+			name:     "Only punctuation",
+			input:    "!@#$%",
+			expected: [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SplitFragments(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import "strings"
+
+const (
+	// zwnj is the zero-width non-joiner that separates the parts of a Persian
+	// word such as "می‌روم" without breaking it into two words.
+	zwnj = '\u200c'
+	zwj  = '\u200d'
+)
+
+// NormalizePersian rewrites Arabic letter variants to their Persian forms,
+// removes diacritics and tatweel and converts Persian and Arabic-Indic digits
+// to ASCII, so the same word is always spelled the same way.
+func NormalizePersian(text string) string {
+	return strings.Map(normalizePersianRune, text)
+}
+
+// NormalizeLetters rewrites the letter variants of word to the forms used by
+// language. In Persian text Arabic yeh, kaf and teh marbuta become Persian
+// letters; other languages, Arabic among them, keep their letters as written.
+func NormalizeLetters(language, word string) string {
+	if language != "fa" || strings.IndexFunc(word, isArabicVariant) < 0 {
+		return word
+	}
+	return strings.Map(persianLetter, word)
+}
+
+// normalizePersianRune returns the normalized form of r in Persian text, or
+// -1 if r is dropped.
+func normalizePersianRune(r rune) rune {
+	if r = normalizeScriptRune(r); r < 0 {
+		return r
+	}
+	return persianLetter(r)
+}
+
+// normalizeScriptRune returns the form of r shared by every language written
+// in the Arabic script, or -1 if r is dropped: diacritics and tatweel are
+// removed and Persian and Arabic-Indic digits become ASCII.
+func normalizeScriptRune(r rune) rune {
+	switch {
+	case r == '\u0640' || r == zwj: // tatweel
+		return -1
+	case r >= '\u064b' && r <= '\u065f', r == '\u0670': // harakat, superscript alef
+		return -1
+	case r >= '۰' && r <= '۹': // persian digits
+		return '0' + (r - '۰')
+	case r >= '٠' && r <= '٩': // arabic-indic digits
+		return '0' + (r - '٠')
+	}
+	return r
+}
+
+// persianLetter returns the Persian form of an Arabic letter variant.
+func persianLetter(r rune) rune {
+	switch r {
+	case 'ي', 'ى': // arabic yeh, alef maksura
+		return 'ی' // persian yeh
+	case 'ك': // arabic kaf
+		return 'ک' // persian keheh
+	case 'ة', 'ۀ': // teh marbuta, heh with yeh above
+		return 'ه' // heh
+	}
+	return r
+}
+
+func isArabicVariant(r rune) bool {
+	return persianLetter(r) != r
+}
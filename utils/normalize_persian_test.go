@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestNormalizePersian(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Arabic yeh and kaf",
+			input:    "كتابي",
+			expected: "کتابی",
+		},
+		{
+			name:     "Alef maksura",
+			input:    "موسى",
+			expected: "موسی",
+		},
+		{
+			name:     "Diacritics removed",
+			input:    "ک\u0650تاب\u0652",
+			expected: "کتاب",
+		},
+		{
+			name:     "Tatweel removed",
+			input:    "ک\u0640\u0640\u0640تاب",
+			expected: "کتاب",
+		},
+		{
+			name:     "Persian and Arabic digits",
+			input:    "۱۴۰۳ و ٢٠٢٤",
+			expected: "1403 و 2024",
+		},
+		{
+			name:     "ZWNJ kept",
+			input:    "می\u200cروم",
+			expected: "می\u200cروم",
+		},
+		{
+			name:     "Latin text unchanged",
+			input:    "Hello World",
+			expected: "Hello World",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NormalizePersian(tt.input); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Persian",
+			language: "fa",
+			input:    "كتابي مدرسة",
+			expected: "کتابی مدرسه",
+		},
+		{
+			name:     "Arabic unchanged",
+			language: "ar",
+			input:    "كتابي مدرسة",
+			expected: "كتابي مدرسة",
+		},
+		{
+			name:     "Unknown language unchanged",
+			language: "",
+			input:    "كتابي",
+			expected: "كتابي",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NormalizeLetters(tt.language, tt.input); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
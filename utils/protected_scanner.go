@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dottedSuffixes are the suffixes of dotted library and framework names,
+// longest first.
+var dottedSuffixes = []string{"net", "js", "ts", "io", "py", "rs"}
+
+// versionedNames holds the VersionedNames for lookup.
+var versionedNames = func() map[string]bool {
+	names := make(map[string]bool, len(VersionedNames))
+	for _, name := range VersionedNames {
+		names[name] = true
+	}
+	return names
+}()
+
+// builtinMatches returns the matches of the DefaultProtectedPatterns in text,
+// leftmost first and the longest at each position, like the compiled
+// patterns would. It scans the runes of text once and only tries to match at
+// the start of a word, as matches starting inside a word are ignored anyway.
+// Matches that end inside a word are returned for protectedMatches to drop.
+func builtinMatches(text string) [][2]int {
+	var matches [][2]int
+	previousWord := false
+	for i := 0; i < len(text); {
+		r, size := decodeRune(text, i)
+		if !previousWord && isAlphanumeric(r) {
+			if end := matchBuiltin(text, i, r); end > i {
+				matches = append(matches, [2]int{i, end})
+				last, _ := utf8.DecodeLastRuneInString(text[:end])
+				previousWord = isWordRune(last)
+				i = end
+				continue
+			}
+		}
+		previousWord = isWordRune(r)
+		i += size
+	}
+	return matches
+}
+
+// matchBuiltin returns the end of the longest match of the protected
+// patterns at i, where text holds first, or -1 if there is none.
+func matchBuiltin(text string, i int, first rune) int {
+	runEnd, letters, numbers := alphanumericRun(text, i)
+
+	end := -1
+	// words mixing letters and digits: IPv6, ES2015, x86, 5G
+	if letters && numbers {
+		end = runEnd
+	}
+	if !unicode.IsLetter(first) {
+		return end
+	}
+
+	// names with a numeric suffix: COVID-19, GPT-4, SARS-CoV-2
+	end = max(end, numericSuffix(text, runEnd))
+	// languages and tools with symbol suffixes: C++, g++, C#, F#
+	if strings.HasPrefix(text[runEnd:], "++") {
+		end = max(end, runEnd+2)
+	}
+	if strings.IndexByte("cfjCFJ", text[i]) >= 0 && i+1 < len(text) && text[i+1] == '#' {
+		end = max(end, i+2)
+	}
+	// dotted library and framework names: node.js, vue.js, asp.net, socket.io
+	end = max(end, dottedSuffix(text, runEnd))
+
+	// known products followed by a dotted version: Go 1.24, Python 3.12;
+	// the names are words, so a name is the whole run
+	if versionedNames[text[i:runEnd]] {
+		end = max(end, version(text, runEnd, false))
+	}
+	// capitalized names followed by a "v" version: Envoy v1.29
+	if unicode.IsUpper(first) {
+		end = max(end, version(text, letterRun(text, i), true))
+	}
+	return end
+}
+
+// numericSuffix returns the end of the hyphenated words at i whose last word
+// starts with a number, or -1 if there are none.
+func numericSuffix(text string, i int) int {
+	for i+1 < len(text) && text[i] == '-' {
+		r, _ := decodeRune(text, i+1)
+		if unicode.IsNumber(r) {
+			end, _, _ := alphanumericRun(text, i+1)
+			return end
+		}
+		if !unicode.IsLetter(r) {
+			break
+		}
+		i, _, _ = alphanumericRun(text, i+1)
+	}
+	return -1
+}
+
+// dottedSuffix returns the end of a dotted suffix at i, ignoring case, or -1
+// if there is none.
+func dottedSuffix(text string, i int) int {
+	if i >= len(text) || text[i] != '.' {
+		return -1
+	}
+	for _, suffix := range dottedSuffixes {
+		if end := i + 1 + len(suffix); end <= len(text) && strings.EqualFold(text[i+1:end], suffix) {
+			return end
+		}
+	}
+	return -1
+}
+
+// version returns the end of a blank and a dotted version number at i, as in
+// " 1.24" or " v1.29", or -1 if there is none. The "v" prefix is optional
+// unless requireV is set.
+func version(text string, i int, requireV bool) int {
+	if i >= len(text) || text[i] != ' ' && text[i] != '\t' {
+		return -1
+	}
+	i++
+	if i < len(text) && text[i] == 'v' {
+		i++
+	} else if requireV {
+		return -1
+	}
+
+	end := numberRun(text, i)
+	if end == i {
+		return -1
+	}
+	parts := 0
+	for end < len(text) && text[end] == '.' {
+		next := numberRun(text, end+1)
+		if next == end+1 {
+			break
+		}
+		end = next
+		parts++
+	}
+	if parts == 0 {
+		return -1
+	}
+	return end
+}
+
+// alphanumericRun returns the end of the run of letters and numbers at i and
+// whether it holds any of each.
+func alphanumericRun(text string, i int) (end int, letters, numbers bool) {
+	for i < len(text) {
+		r, size := decodeRune(text, i)
+		switch {
+		case unicode.IsLetter(r):
+			letters = true
+		case unicode.IsNumber(r):
+			numbers = true
+		default:
+			return i, letters, numbers
+		}
+		i += size
+	}
+	return i, letters, numbers
+}
+
+// letterRun returns the end of the run of letters at i.
+func letterRun(text string, i int) int {
+	for i < len(text) {
+		r, size := decodeRune(text, i)
+		if !unicode.IsLetter(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// numberRun returns the end of the run of numbers at i.
+func numberRun(text string, i int) int {
+	for i < len(text) {
+		r, size := decodeRune(text, i)
+		if !unicode.IsNumber(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// isAlphanumeric reports whether r is a letter or a number.
+func isAlphanumeric(r rune) bool {
+	if r < utf8.RuneSelf {
+		return asciiWordBytes[r]
+	}
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// decodeRune returns the rune at i and its size, without decoding ASCII.
+func decodeRune(text string, i int) (rune, int) {
+	if c := text[i]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(text[i:])
+}
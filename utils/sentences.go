@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sentence is a sentence of a text with its byte offsets in that text.
+type Sentence struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// abbreviations end in a period that does not end a sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true,
+	"st": true, "vs": true, "etc": true, "e.g": true, "i.e": true, "inc": true, "ltd": true,
+	"co": true, "corp": true, "no": true, "fig": true, "approx": true, "u.s": true,
+	"a.m": true, "p.m": true,
+}
+
+// SplitSentences splits text into sentences at sentence-ending punctuation
+// followed by a space and at line breaks. Periods after abbreviations and
+// initials, and inside numbers, do not end a sentence. Empty sentences are
+// dropped.
+func SplitSentences(text string) []Sentence {
+	sentences := []Sentence{}
+	start := 0
+	emit := func(end int) {
+		if s := trimmedSentence(text, start, end); s.Text != "" {
+			sentences = append(sentences, s)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\n':
+			emit(i)
+		case isSentenceEnd(r):
+			end := i + size
+			// keep runs of punctuation and closing quotes with the sentence
+			for end < len(text) {
+				next, n := utf8.DecodeRuneInString(text[end:])
+				if !isSentenceEnd(next) && !isClosingPunctuation(next) {
+					break
+				}
+				end += n
+			}
+			if next, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && !unicode.IsSpace(next) {
+				i = end
+				continue
+			}
+			if r == '.' && isAbbreviation(text[start:i]) {
+				i = end
+				continue
+			}
+			emit(end)
+			i = end
+			continue
+		}
+		i += size
+	}
+	emit(len(text))
+	return sentences
+}
+
+// trimmedSentence returns text[start:end] without surrounding space.
+func trimmedSentence(text string, start, end int) Sentence {
+	s := text[start:end]
+	trimmed := strings.TrimLeftFunc(s, unicode.IsSpace)
+	start += len(s) - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	return Sentence{Text: trimmed, Start: start, End: start + len(trimmed)}
+}
+
+func isSentenceEnd(r rune) bool {
+	switch r {
+	case '.', '!', '?', '؟', '…', '۔':
+		return true
+	}
+	return false
+}
+
+func isClosingPunctuation(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '»', '”', '’':
+		return true
+	}
+	return false
+}
+
+// isAbbreviation reports whether the last word of text, which a period
+// follows, is a known abbreviation or a single letter such as an initial.
+func isAbbreviation(text string) bool {
+	word := text[strings.LastIndexFunc(text, unicode.IsSpace)+1:]
+	word = strings.ToLower(strings.TrimLeft(word, "\"'(["))
+	return abbreviations[word] || utf8.RuneCountInString(word) == 1 && unicode.IsLetter([]rune(word)[0])
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Punctuation",
+			input:    "Go is fast. Is it simple? It is!  Try it.",
+			expected: []string{"Go is fast.", "Is it simple?", "It is!", "Try it."},
+		},
+		{
+			name:     "Abbreviations and initials",
+			input:    "Dr. Smith met J. R. Doe at 5 p.m. on Monday, e.g. after lunch. They talked.",
+			expected: []string{"Dr. Smith met J. R. Doe at 5 p.m. on Monday, e.g. after lunch.", "They talked."},
+		},
+		{
+			name:     "Numbers and versions",
+			input:    "Go 1.18 added generics. Version 1.24 ships 3.5% faster builds.",
+			expected: []string{"Go 1.18 added generics.", "Version 1.24 ships 3.5% faster builds."},
+		},
+		{
+			name:     "Closing quotes",
+			input:    `He said "it works." Then he left...  Really?!`,
+			expected: []string{`He said "it works."`, "Then he left...", "Really?!"},
+		},
+		{
+			name:     "Line breaks",
+			input:    "A Heading\n\nFirst paragraph without a period\nSecond line.",
+			expected: []string{"A Heading", "First paragraph without a period", "Second line."},
+		},
+		{
+			name:     "Persian",
+			input:    "زبان گو سریع است. آیا ساده است؟ بله",
+			expected: []string{"زبان گو سریع است.", "آیا ساده است؟", "بله"},
+		},
+		{
+			name:     "Empty text",
+			input:    "  \n ",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentences := SplitSentences(tt.input)
+			texts := []string{}
+			for _, s := range sentences {
+				texts = append(texts, s.Text)
+				if tt.input[s.Start:s.End] != s.Text {
+					t.Errorf("Offsets %d-%d do not match %q", s.Start, s.End, s.Text)
+				}
+			}
+			if !reflect.DeepEqual(texts, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, texts)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package utils
+
+import "strings"
+
+// porter2Exceptions are words the English (Porter2) stemmer maps to a fixed
+// stem or leaves untouched.
+var porter2Exceptions = map[string]string{
+	"skis": "ski", "skies": "sky", "dying": "die", "lying": "lie", "tying": "tie",
+	"idly": "idl", "gently": "gentl", "ugly": "ugli", "early": "earli", "only": "onli",
+	"singly": "singl", "sky": "sky", "news": "news", "howe": "howe", "atlas": "atlas",
+	"cosmos": "cosmos", "bias": "bias", "andes": "andes",
+}
+
+// porter2Step1aInvariants are left unchanged once step 1a has run.
+var porter2Step1aInvariants = map[string]bool{
+	"inning": true, "outing": true, "canning": true, "herring": true,
+	"earring": true, "proceed": true, "exceed": true, "succeed": true,
+}
+
+// StemEnglish reduces an English word to its Porter2 (Snowball English) stem.
+// Words containing anything but ASCII letters and apostrophes are returned
+// unchanged.
+func StemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if (c < 'a' || c > 'z') && c != '\'' {
+			return word
+		}
+	}
+	if stem, ok := porter2Exceptions[word]; ok {
+		return stem
+	}
+
+	s := &porter2{w: []byte(strings.TrimPrefix(word, "'"))}
+	s.markConsonantY()
+	s.r1, s.r2 = s.regions()
+
+	s.step0()
+	s.step1a()
+	if porter2Step1aInvariants[string(s.w)] {
+		return string(s.w)
+	}
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+
+	return strings.ToLower(string(s.w))
+}
+
+type porter2 struct {
+	w      []byte
+	r1, r2 int
+}
+
+func isPorterVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// markConsonantY marks an initial y and a y after a vowel as the consonant Y.
+func (s *porter2) markConsonantY() {
+	for i, c := range s.w {
+		if c == 'y' && (i == 0 || isPorterVowel(s.w[i-1])) {
+			s.w[i] = 'Y'
+		}
+	}
+}
+
+// regions returns the start of R1 and R2.
+func (s *porter2) regions() (int, int) {
+	r1 := len(s.w)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(s.w), prefix) {
+			r1 = len(prefix)
+			break
+		}
+	}
+	if r1 == len(s.w) {
+		r1 = s.regionAfter(0)
+	}
+	return r1, s.regionAfter(r1)
+}
+
+// regionAfter returns the position after the first non-vowel that follows a
+// vowel at or after start.
+func (s *porter2) regionAfter(start int) int {
+	for i := start + 1; i < len(s.w); i++ {
+		if !isPorterVowel(s.w[i]) && isPorterVowel(s.w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(s.w)
+}
+
+func (s *porter2) hasSuffix(suffix string) bool {
+	return strings.HasSuffix(string(s.w), suffix)
+}
+
+// longestSuffix returns the longest of suffixes that ends the word.
+func (s *porter2) longestSuffix(suffixes ...string) string {
+	longest := ""
+	for _, suffix := range suffixes {
+		if len(suffix) > len(longest) && s.hasSuffix(suffix) {
+			longest = suffix
+		}
+	}
+	return longest
+}
+
+func (s *porter2) inR1(suffix string) bool {
+	return len(s.w)-len(suffix) >= s.r1
+}
+
+func (s *porter2) inR2(suffix string) bool {
+	return len(s.w)-len(suffix) >= s.r2
+}
+
+func (s *porter2) replace(suffix, replacement string) {
+	s.w = append(s.w[:len(s.w)-len(suffix)], replacement...)
+}
+
+func (s *porter2) containsVowel(end int) bool {
+	for i := 0; i < end; i++ {
+		if isPorterVowel(s.w[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsWithShortSyllable reports whether w[:end] ends in a short syllable.
+func (s *porter2) endsWithShortSyllable(end int) bool {
+	if end == 2 {
+		return isPorterVowel(s.w[0]) && !isPorterVowel(s.w[1])
+	}
+	if end < 3 {
+		return false
+	}
+	a, b, c := s.w[end-3], s.w[end-2], s.w[end-1]
+	return !isPorterVowel(a) && isPorterVowel(b) && !isPorterVowel(c) && c != 'w' && c != 'x' && c != 'Y'
+}
+
+func (s *porter2) isShort() bool {
+	return s.r1 >= len(s.w) && s.endsWithShortSyllable(len(s.w))
+}
+
+func (s *porter2) step0() {
+	if suffix := s.longestSuffix("'", "'s", "'s'"); suffix != "" {
+		s.replace(suffix, "")
+	}
+}
+
+func (s *porter2) step1a() {
+	switch suffix := s.longestSuffix("sses", "ied", "ies", "s", "us", "ss"); suffix {
+	case "sses":
+		s.replace(suffix, "ss")
+	case "ied", "ies":
+		if len(s.w) > 4 {
+			s.replace(suffix, "i")
+		} else {
+			s.replace(suffix, "ie")
+		}
+	case "s":
+		if s.containsVowel(len(s.w) - 2) {
+			s.replace(suffix, "")
+		}
+	}
+}
+
+func (s *porter2) step1b() {
+	switch suffix := s.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly"); suffix {
+	case "eed", "eedly":
+		if s.inR1(suffix) {
+			s.replace(suffix, "ee")
+		}
+	case "ed", "edly", "ing", "ingly":
+		if !s.containsVowel(len(s.w) - len(suffix)) {
+			return
+		}
+		s.replace(suffix, "")
+		switch {
+		case s.hasSuffix("at"), s.hasSuffix("bl"), s.hasSuffix("iz"):
+			s.w = append(s.w, 'e')
+		case s.hasDoubleEnding():
+			s.w = s.w[:len(s.w)-1]
+		case s.isShort():
+			s.w = append(s.w, 'e')
+		}
+	}
+}
+
+func (s *porter2) hasDoubleEnding() bool {
+	if len(s.w) < 2 {
+		return false
+	}
+	a, b := s.w[len(s.w)-2], s.w[len(s.w)-1]
+	if a != b {
+		return false
+	}
+	switch b {
+	case 'b', 'd', 'f', 'g', 'm', 'n', 'p', 'r', 't':
+		return true
+	}
+	return false
+}
+
+func (s *porter2) step1c() {
+	n := len(s.w)
+	if n > 2 && (s.w[n-1] == 'y' || s.w[n-1] == 'Y') && !isPorterVowel(s.w[n-2]) {
+		s.w[n-1] = 'i'
+	}
+}
+
+var porter2Step2 = map[string]string{
+	"tional": "tion", "enci": "ence", "anci": "ance", "abli": "able", "entli": "ent",
+	"izer": "ize", "ization": "ize", "ational": "ate", "ation": "ate", "ator": "ate",
+	"alism": "al", "aliti": "al", "alli": "al", "fulness": "ful", "ousli": "ous",
+	"ousness": "ous", "iveness": "ive", "iviti": "ive", "biliti": "ble", "bli": "ble",
+	"ogi": "og", "fulli": "ful", "lessli": "less", "li": "",
+}
+
+func (s *porter2) step2() {
+	suffix := s.longestSuffix(keys(porter2Step2)...)
+	if suffix == "" || !s.inR1(suffix) {
+		return
+	}
+	switch suffix {
+	case "ogi":
+		if len(s.w) < 4 || s.w[len(s.w)-4] != 'l' {
+			return
+		}
+	case "li":
+		if len(s.w) < 3 || !strings.ContainsRune("cdeghkmnrt", rune(s.w[len(s.w)-3])) {
+			return
+		}
+	}
+	s.replace(suffix, porter2Step2[suffix])
+}
+
+var porter2Step3 = map[string]string{
+	"tional": "tion", "ational": "ate", "alize": "al", "icate": "ic", "iciti": "ic",
+	"ical": "ic", "ful": "", "ness": "", "ative": "",
+}
+
+func (s *porter2) step3() {
+	suffix := s.longestSuffix(keys(porter2Step3)...)
+	if suffix == "" || !s.inR1(suffix) {
+		return
+	}
+	if suffix == "ative" && !s.inR2(suffix) {
+		return
+	}
+	s.replace(suffix, porter2Step3[suffix])
+}
+
+var porter2Step4 = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion",
+}
+
+func (s *porter2) step4() {
+	suffix := s.longestSuffix(porter2Step4...)
+	if suffix == "" || !s.inR2(suffix) {
+		return
+	}
+	if suffix == "ion" {
+		n := len(s.w) - len(suffix)
+		if n == 0 || (s.w[n-1] != 's' && s.w[n-1] != 't') {
+			return
+		}
+	}
+	s.replace(suffix, "")
+}
+
+func (s *porter2) step5() {
+	switch {
+	case s.hasSuffix("e"):
+		if s.inR2("e") || (s.inR1("e") && !s.endsWithShortSyllable(len(s.w)-1)) {
+			s.replace("e", "")
+		}
+	case s.hasSuffix("ll"):
+		if s.inR2("l") {
+			s.replace("l", "")
+		}
+	}
+}
+
+func keys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
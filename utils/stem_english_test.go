@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestStemEnglish(t *testing.T) {
+	tests := map[string]string{
+		// step 0 and 1a
+		"caresses": "caress", "ponies": "poni", "ties": "tie", "cats": "cat",
+		"gas": "gas", "this": "this", "kiwis": "kiwi", "john's": "john",
+		// step 1b
+		"agreed": "agre", "feed": "feed", "running": "run", "runs": "run",
+		"run": "run", "hopping": "hop", "hoped": "hope", "filing": "file",
+		"conflated": "conflat", "troubled": "troubl", "sized": "size",
+		"luxuriating": "luxuri", "hissing": "hiss", "failing": "fail",
+		// step 1c
+		"cry": "cri", "by": "by", "say": "say", "happy": "happi",
+		// step 2 to 5
+		"relational": "relat", "conditional": "condit", "rational": "ration",
+		"valenci": "valenc", "digitizer": "digit", "operator": "oper",
+		"feudalism": "feudal", "decisiveness": "decis", "hopefulness": "hope",
+		"callousness": "callous", "formaliti": "formal", "sensitiviti": "sensit",
+		"sensibiliti": "sensibl", "generalization": "general",
+		"triplicate": "triplic", "formative": "format", "formalize": "formal",
+		"electrical": "electr", "hopeful": "hope", "goodness": "good",
+		"revival": "reviv", "allowance": "allow", "inference": "infer",
+		"airliner": "airlin", "adjustable": "adjust", "defensible": "defens",
+		"irritant": "irrit", "replacement": "replac", "adjustment": "adjust",
+		"dependent": "depend", "adoption": "adopt", "communism": "communism",
+		"activate": "activ", "angulariti": "angular", "homologous": "homolog",
+		"effective": "effect", "bowdlerize": "bowdler", "controll": "control",
+		"roll": "roll", "generate": "generat", "generous": "generous",
+		"consign": "consign", "consigned": "consign", "consignment": "consign",
+		"knightly": "knight", "kneeling": "kneel", "languages": "languag",
+		"language": "languag", "programming": "program", "programs": "program",
+		"abilities": "abil", "absolutely": "absolut", "accompanying": "accompani",
+		"according": "accord", "abatements": "abat", "abbreviations": "abbrevi",
+		// exceptions
+		"skies": "sky", "dying": "die", "news": "news", "only": "onli",
+		"inning": "inning", "succeed": "succeed", "exceed": "exceed",
+		// untouched
+		"go": "go", "über": "über", "café": "café",
+	}
+
+	for word, expected := range tests {
+		t.Run(word, func(t *testing.T) {
+			if result := StemEnglish(word); result != expected {
+				t.Errorf("Expected '%s' to stem to '%s', got '%s'", word, expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// persianSuffixes are stripped from Persian words, longest first. Suffixes
+// attached with a zero-width non-joiner are unambiguous; the joined forms of
+// the plural and superlative are common enough in practice to strip as well.
+var persianSuffixes = []string{
+	"\u200cهایی", "\u200cهای", "\u200cترین", "\u200cها", "\u200cتر", "\u200cای",
+	"\u200cشان", "\u200cتان", "\u200cمان", "\u200cام", "\u200cات", "\u200cاش", "\u200cی",
+	"هایی", "ترین", "های", "ها",
+}
+
+// persianPrefixes are verb and adjective prefixes written with a zero-width
+// non-joiner.
+var persianPrefixes = []string{"نمی\u200c", "می\u200c", "بی\u200c"}
+
+// minPersianStem is the fewest letters a Persian stem may keep.
+const minPersianStem = 2
+
+// StemPersian strips common inflectional affixes from a normalized Persian
+// word. An affix is only removed when at least minPersianStem letters remain.
+func StemPersian(word string) string {
+	for _, suffix := range persianSuffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && utf8.RuneCountInString(stem) >= minPersianStem {
+			word = stem
+			break
+		}
+	}
+	for _, prefix := range persianPrefixes {
+		if stem, ok := strings.CutPrefix(word, prefix); ok && utf8.RuneCountInString(stem) >= minPersianStem {
+			word = stem
+			break
+		}
+	}
+	return strings.Trim(word, string(zwnj))
+}
+
+// Stem reduces word to its stem using the stemmer for language. Languages
+// without a stemmer return the word unchanged.
+func Stem(language, word string) string {
+	switch language {
+	case "en":
+		return StemEnglish(word)
+	case "fa":
+		return StemPersian(word)
+	}
+	return word
+}
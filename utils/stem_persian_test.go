@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestStemPersian(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Plural with ZWNJ", input: "کتاب\u200cها", expected: "کتاب"},
+		{name: "Plural with ezafe", input: "کتاب\u200cهای", expected: "کتاب"},
+		{name: "Joined plural", input: "درختها", expected: "درخت"},
+		{name: "Superlative", input: "بزرگ\u200cترین", expected: "بزرگ"},
+		{name: "Comparative", input: "بزرگ\u200cتر", expected: "بزرگ"},
+		{name: "Possessive", input: "کتاب\u200cشان", expected: "کتاب"},
+		{name: "Indefinite", input: "خانه\u200cای", expected: "خانه"},
+		{name: "Verb prefix", input: "می\u200cروم", expected: "روم"},
+		{name: "Negative verb prefix", input: "نمی\u200cدانم", expected: "دانم"},
+		{name: "Too short to strip", input: "ها", expected: "ها"},
+		{name: "Word without affix", input: "ماشین", expected: "ماشین"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := StemPersian(tt.input); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		language string
+		word     string
+		expected string
+	}{
+		{language: "en", word: "running", expected: "run"},
+		{language: "fa", word: "کتاب\u200cها", expected: "کتاب"},
+		{language: "de", word: "laufen", expected: "laufen"},
+		{language: "", word: "running", expected: "running"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+"/"+tt.word, func(t *testing.T) {
+			if result := Stem(tt.language, tt.word); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultLanguage is used when the language of a text cannot be detected or
+// has no stop-word list of its own.
+const DefaultLanguage = "en"
+
+// stopWordExt is the extension of stop-word list files. The file name without
+// it is the language code.
+const stopWordExt = ".txt"
+
+//go:embed languages/stopwords/*.txt
+var stopWordFiles embed.FS
+
+// StopWords is an immutable set of stop-word lists by language, with optional
+// per-tenant overrides. The active set is swapped as a whole, so readers never
+// see a half-loaded list.
+type StopWords struct {
+	languages map[string]map[string]bool
+	tenants   map[string]map[string]map[string]bool
+}
+
+// embeddedStopWords holds the lists shipped in the binary. Language detection
+// is built from them, so detection does not change when the lists are reloaded.
+var embeddedStopWords = loadEmbeddedStopWords()
+
+// activeStopWords holds the lists used by IsStopWord, IsStopWordIn and
+// IsStopWordFor.
+var activeStopWords atomic.Pointer[StopWords]
+
+func init() {
+	activeStopWords.Store(DefaultStopWords())
+}
+
+// DefaultStopWords returns the stop-word lists embedded in the binary.
+func DefaultStopWords() *StopWords {
+	languages := make(map[string]map[string]bool, len(embeddedStopWords))
+	for language, words := range embeddedStopWords {
+		languages[language] = words
+	}
+	return &StopWords{
+		languages: languages,
+		tenants:   map[string]map[string]map[string]bool{},
+	}
+}
+
+func loadEmbeddedStopWords() map[string]map[string]bool {
+	entries, err := stopWordFiles.ReadDir("languages/stopwords")
+	if err != nil {
+		panic(err)
+	}
+
+	lists := make(map[string]map[string]bool, len(entries))
+	for _, entry := range entries {
+		data, err := stopWordFiles.ReadFile(path.Join("languages/stopwords", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		language := strings.TrimSuffix(entry.Name(), stopWordExt)
+		lists[language] = parseStopWords(language, string(data))
+	}
+	return lists
+}
+
+// LoadStopWords layers the lists found at paths over the embedded defaults.
+// Each path is either a "<language>.txt" file or a directory holding
+// "<language>.txt" files and "<tenant>/<language>.txt" tenant overrides.
+// Files extend the list they override; a word prefixed with '!' removes it.
+func LoadStopWords(paths []string) (*StopWords, error) {
+	s := DefaultStopWords()
+	tenantFiles := make(map[string]map[string][]string)
+
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if err := s.applyLanguageFile(root); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			name := filepath.Join(root, entry.Name())
+			if !entry.IsDir() {
+				if strings.HasSuffix(entry.Name(), stopWordExt) {
+					if err := s.applyLanguageFile(name); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+
+			tenant := entry.Name()
+			files, err := os.ReadDir(name)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				if file.IsDir() || !strings.HasSuffix(file.Name(), stopWordExt) {
+					continue
+				}
+				language := strings.TrimSuffix(file.Name(), stopWordExt)
+				if tenantFiles[tenant] == nil {
+					tenantFiles[tenant] = make(map[string][]string)
+				}
+				tenantFiles[tenant][language] = append(tenantFiles[tenant][language], filepath.Join(name, file.Name()))
+			}
+		}
+	}
+
+	// tenant overrides apply on top of the final language lists, whatever
+	// order the paths were given in
+	for tenant, languages := range tenantFiles {
+		s.tenants[tenant] = make(map[string]map[string]bool, len(languages))
+		for language, files := range languages {
+			words := copyStopWords(s.list(language))
+			for _, file := range files {
+				override, err := readStopWordFile(language, file)
+				if err != nil {
+					return nil, err
+				}
+				mergeStopWords(words, override)
+			}
+			s.tenants[tenant][language] = words
+		}
+	}
+	return s, nil
+}
+
+func (s *StopWords) applyLanguageFile(name string) error {
+	if !strings.HasSuffix(name, stopWordExt) {
+		return fmt.Errorf("stop-word file %s: expected a %s extension", name, stopWordExt)
+	}
+	language := strings.TrimSuffix(filepath.Base(name), stopWordExt)
+	override, err := readStopWordFile(language, name)
+	if err != nil {
+		return err
+	}
+
+	// a language without an embedded list starts empty rather than from the
+	// default language
+	words := copyStopWords(s.languages[language])
+	mergeStopWords(words, override)
+	s.languages[language] = words
+	return nil
+}
+
+func readStopWordFile(language, name string) (map[string]bool, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseStopWords(language, string(data)), nil
+}
+
+// list returns the stop words of language, falling back to the default
+// language.
+func (s *StopWords) list(language string) map[string]bool {
+	if words, ok := s.languages[language]; ok {
+		return words
+	}
+	return s.languages[DefaultLanguage]
+}
+
+// Contains reports whether word is a stop word of language for tenant.
+// Tenants without an override for language use the language list.
+func (s *StopWords) Contains(tenant, language, word string) bool {
+	if tenant != "" {
+		if words, ok := s.tenants[tenant][language]; ok {
+			return words[word]
+		}
+	}
+	return s.list(language)[word]
+}
+
+// Size returns the number of language lists and of tenants with overrides.
+func (s *StopWords) Size() (languages, tenants int) {
+	return len(s.languages), len(s.tenants)
+}
+
+// parseStopWords reads whitespace separated words, ignoring lines that start
+// with '#'. Words are normalized the same way the tokens of text in language
+// are. A word prefixed with '!' maps to false, marking it for removal when
+// merged.
+func parseStopWords(language, data string) map[string]bool {
+	words := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, word := range strings.Fields(line) {
+			keep := !strings.HasPrefix(word, "!")
+			word = strings.TrimPrefix(word, "!")
+			if word == "" {
+				continue
+			}
+			words[NormalizeLetters(language, normalizeWord(word))] = keep
+		}
+	}
+	return words
+}
+
+func copyStopWords(words map[string]bool) map[string]bool {
+	c := make(map[string]bool, len(words))
+	for word, keep := range words {
+		if keep {
+			c[word] = true
+		}
+	}
+	return c
+}
+
+func mergeStopWords(words, override map[string]bool) {
+	for word, keep := range override {
+		if keep {
+			words[word] = true
+		} else {
+			delete(words, word)
+		}
+	}
+}
+
+// SetStopWords atomically replaces the active stop-word lists. Extraction
+// that took a snapshot with CurrentStopWords keeps using it until it is done.
+func SetStopWords(s *StopWords) {
+	activeStopWords.Store(s)
+}
+
+// CurrentStopWords returns the active stop-word lists. Callers filtering a
+// whole document should take one snapshot so a concurrent reload cannot mix
+// two lists within the same document.
+func CurrentStopWords() *StopWords {
+	return activeStopWords.Load()
+}
+
+// IsStopWord reports whether word is a stop word of the default language.
+func IsStopWord(word string) bool {
+	return IsStopWordIn(DefaultLanguage, word)
+}
+
+// IsStopWordIn reports whether word is a stop word of language. Languages
+// without a list fall back to the default language.
+func IsStopWordIn(language, word string) bool {
+	return IsStopWordFor("", language, word)
+}
+
+// IsStopWordFor reports whether word is a stop word of language, using the
+// overrides of tenant when there are any.
+func IsStopWordFor(tenant, language, word string) bool {
+	return activeStopWords.Load().Contains(tenant, language, word)
+}
+
+// StopWordFiles returns every stop-word file LoadStopWords would read from
+// paths. Missing paths are skipped.
+func StopWordFiles(paths []string) []string {
+	var files []string
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			files = append(files, root)
+			continue
+		}
+		for _, pattern := range []string{"*" + stopWordExt, filepath.Join("*", "*"+stopWordExt)} {
+			matches, _ := filepath.Glob(filepath.Join(root, pattern))
+			files = append(files, matches...)
+		}
+	}
+	return files
+}
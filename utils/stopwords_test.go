@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsStopWordIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		word     string
+		expected bool
+	}{
+		{name: "English stop word", language: "en", word: "the", expected: true},
+		{name: "English content word", language: "en", word: "kubernetes", expected: false},
+		{name: "German stop word", language: "de", word: "und", expected: true},
+		{name: "German word in English list", language: "en", word: "und", expected: false},
+		{name: "French stop word", language: "fr", word: "avec", expected: true},
+		{name: "Spanish stop word", language: "es", word: "porque", expected: true},
+		{name: "Persian stop word", language: "fa", word: "است", expected: true},
+		{name: "Arabic stop word", language: "ar", word: "في", expected: true},
+		{name: "Arabic stop word in Persian spelling", language: "ar", word: "فی", expected: false},
+		{name: "Unknown language falls back", language: "xx", word: "the", expected: true},
+		{name: "Empty language falls back", language: "", word: "with", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsStopWordIn(tt.language, tt.word); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseStopWords(t *testing.T) {
+	words := parseStopWords("fa", "# comment\nThe a\n\n  an  \nكه\n")
+
+	for _, word := range []string{"the", "a", "an", "که"} {
+		if !words[word] {
+			t.Errorf("Expected '%s' to be a stop word", word)
+		}
+	}
+	if words["#"] || words["comment"] {
+		t.Error("Comment lines should be ignored")
+	}
+	if len(words) != 4 {
+		t.Errorf("Expected 4 stop words, got %d: %v", len(words), words)
+	}
+}
+
+func writeStopWordFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadStopWords(t *testing.T) {
+	dir := t.TempDir()
+	writeStopWordFile(t, filepath.Join(dir, "en.txt"), "# newsroom additions\nbreaking\n!with\n")
+	writeStopWordFile(t, filepath.Join(dir, "it.txt"), "della\n")
+	writeStopWordFile(t, filepath.Join(dir, "acme", "en.txt"), "acme\n!breaking\n")
+	extra := filepath.Join(t.TempDir(), "de.txt")
+	writeStopWordFile(t, extra, "heute\n")
+
+	stopWords, err := LoadStopWords([]string{dir, extra})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		tenant   string
+		language string
+		word     string
+		expected bool
+	}{
+		{name: "Embedded word kept", language: "en", word: "the", expected: true},
+		{name: "Word added", language: "en", word: "breaking", expected: true},
+		{name: "Word removed", language: "en", word: "with", expected: false},
+		{name: "New language", language: "it", word: "della", expected: true},
+		{name: "New language has no embedded words", language: "it", word: "the", expected: false},
+		{name: "Single file", language: "de", word: "heute", expected: true},
+		{name: "Single file keeps embedded words", language: "de", word: "und", expected: true},
+		{name: "Tenant word added", tenant: "acme", language: "en", word: "acme", expected: true},
+		{name: "Tenant word removed", tenant: "acme", language: "en", word: "breaking", expected: false},
+		{name: "Tenant inherits language list", tenant: "acme", language: "en", word: "the", expected: true},
+		{name: "Tenant inherits language removal", tenant: "acme", language: "en", word: "with", expected: false},
+		{name: "Tenant without override", tenant: "acme", language: "de", word: "heute", expected: true},
+		{name: "Other tenant", tenant: "globex", language: "en", word: "acme", expected: false},
+		{name: "Unknown language falls back", language: "xx", word: "breaking", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := stopWords.Contains(tt.tenant, tt.language, tt.word); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if files := StopWordFiles([]string{dir, extra}); len(files) != 4 {
+		t.Errorf("Expected 4 stop-word files, got %v", files)
+	}
+}
+
+func TestLoadStopWords_Errors(t *testing.T) {
+	if _, err := LoadStopWords([]string{filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("Expected an error for a missing path")
+	}
+
+	name := filepath.Join(t.TempDir(), "en.list")
+	writeStopWordFile(t, name, "breaking\n")
+	if _, err := LoadStopWords([]string{name}); err == nil {
+		t.Error("Expected an error for a file without the .txt extension")
+	}
+}
+
+func TestSetStopWords(t *testing.T) {
+	t.Cleanup(func() { SetStopWords(DefaultStopWords()) })
+
+	dir := t.TempDir()
+	writeStopWordFile(t, filepath.Join(dir, "en.txt"), "kubernetes\n")
+	stopWords, err := LoadStopWords([]string{dir})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	snapshot := CurrentStopWords()
+	SetStopWords(stopWords)
+
+	if !IsStopWordIn("en", "kubernetes") {
+		t.Error("Expected the new list to be active")
+	}
+	if snapshot.Contains("", "en", "kubernetes") {
+		t.Error("Expected an earlier snapshot to keep the old list")
+	}
+}
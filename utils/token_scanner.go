@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// readerPool and wordPool hold the buffers of closed scanners for reuse.
+var (
+	readerPool = sync.Pool{New: func() any { return bufio.NewReaderSize(nil, 4096) }}
+	wordPool   = sync.Pool{New: func() any { b := make([]byte, 0, 64); return &b }}
+)
+
+// TokenScanner reads the words of a stream one at a time, like
+// bufio.Scanner, splitting and normalizing them like Tokenize. It never holds
+// more than the current word in memory and reuses pooled buffers, so
+// scanning allocates nothing per word unless the text is requested as a
+// string. Protected patterns are not applied.
+//
+//	s := utils.NewTokenScanner(r)
+//	defer s.Close()
+//	for s.Scan() {
+//		word := s.Bytes()
+//		...
+//	}
+//	if err := s.Err(); err != nil { ... }
+type TokenScanner struct {
+	r      *bufio.Reader
+	word   *[]byte
+	offset int
+	start  int
+	end    int
+	done   bool
+	err    error
+}
+
+// NewTokenScanner returns a scanner reading from r. Close it to return its
+// buffers to the pool.
+func NewTokenScanner(r io.Reader) *TokenScanner {
+	reader := readerPool.Get().(*bufio.Reader)
+	reader.Reset(r)
+	return &TokenScanner{r: reader, word: wordPool.Get().(*[]byte)}
+}
+
+// Reset makes the scanner read words from r, keeping its buffers, so one
+// scanner can read many documents.
+func (s *TokenScanner) Reset(r io.Reader) {
+	s.r.Reset(r)
+	*s.word = (*s.word)[:0]
+	s.offset, s.start, s.end = 0, 0, 0
+	s.done, s.err = false, nil
+}
+
+// Scan advances to the next word and reports whether there is one. It
+// returns false at the end of the stream, on a read error and once the
+// scanner is closed.
+func (s *TokenScanner) Scan() bool {
+	if s.done || s.r == nil {
+		return false
+	}
+
+	buf := (*s.word)[:0]
+	s.start = -1
+	for {
+		r, size, err := s.r.ReadRune()
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+			}
+			s.done = true
+			break
+		}
+		if isWordRune(r) || ((r == zwnj || r == zwj) && s.start >= 0) {
+			if s.start < 0 {
+				s.start = s.offset
+			}
+			buf = appendNormalizedRune(buf, r)
+			s.offset += size
+			continue
+		}
+
+		s.offset += size
+		if s.start >= 0 {
+			s.end = s.offset - size
+			if buf = trimJoiners(buf); len(buf) > 0 {
+				*s.word = buf
+				return true
+			}
+			s.start = -1
+		}
+	}
+
+	// the stream ended inside a word
+	*s.word = buf
+	if s.start >= 0 {
+		s.end = s.offset
+		if *s.word = trimJoiners(buf); len(*s.word) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns the normalized current word. The slice is only valid until
+// the next call to Scan.
+func (s *TokenScanner) Bytes() []byte {
+	return *s.word
+}
+
+// Text returns the normalized current word as a string.
+func (s *TokenScanner) Text() string {
+	return string(*s.word)
+}
+
+// Token returns the current word with its byte offsets in the stream.
+func (s *TokenScanner) Token() Token {
+	return Token{Text: s.Text(), Start: s.start, End: s.end}
+}
+
+// Err returns the first read error other than io.EOF.
+func (s *TokenScanner) Err() error {
+	return s.err
+}
+
+// Close returns the buffers of the scanner to the pool. The scanner must not
+// be used afterwards.
+func (s *TokenScanner) Close() {
+	if s.r == nil {
+		return
+	}
+	s.r.Reset(nil)
+	readerPool.Put(s.r)
+	if cap(*s.word) <= 1024 {
+		*s.word = (*s.word)[:0]
+		wordPool.Put(s.word)
+	}
+	s.r, s.word = nil, nil
+}
@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode"
+)
+
+// tokenizerInputs exercise case folding, Persian normalization, joiners,
+// digits and invalid UTF-8.
+var tokenizerInputs = []string{
+	"The Go programming language, created at Google in 2009.",
+	"ÉCOLE Straße İstanbul ΣΊΣΥΦΟΣ",
+	"می‌روم به خانه‌‌ ها‌ كتاب علي ۱۴۰۲ ٣",
+	"مُحَمَّد ـــ کتـــاب ‍زبان‍",
+	"bad \xff\xfe bytes\xc3 and café́",
+	"",
+	"   ...   ",
+}
+
+// referenceTokens is the word tokenizer before scanning was rewritten: it
+// ranges over the runes of text, normalizes every word with strings.Map and
+// strings.ToLower and appends to an unsized slice.
+func referenceTokens(text string) []Token {
+	tokens := []Token{}
+	wordStart := -1
+	appendWord := func(start, end int) {
+		word := strings.TrimRight(strings.Map(normalizeScriptRune, text[start:end]), string(zwnj))
+		if word = strings.ToLower(word); word != "" {
+			tokens = append(tokens, Token{Text: word, Start: start, End: end})
+		}
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) || ((r == zwnj || r == zwj) && wordStart >= 0) {
+			if wordStart < 0 {
+				wordStart = i
+			}
+			continue
+		}
+		if wordStart >= 0 {
+			appendWord(wordStart, i)
+			wordStart = -1
+		}
+	}
+	if wordStart >= 0 {
+		appendWord(wordStart, len(text))
+	}
+	return tokens
+}
+
+// regexpTokenize is the original Tokenize, which compiled its regular
+// expression on every call and only kept ASCII letters.
+func regexpTokenize(text string) []string {
+	words := []string{}
+	reg := regexp.MustCompile(`[^a-zA-Z\s]`)
+	content := reg.ReplaceAllString(text, " ")
+	for _, word := range strings.Fields(content) {
+		words = append(words, strings.ToLower(word))
+	}
+	return words
+}
+
+func TestTokens_MatchReference(t *testing.T) {
+	for _, input := range tokenizerInputs {
+		expected := referenceTokens(input)
+		if tokens := (*Tokenizer)(nil).Tokens(input); !reflect.DeepEqual(tokens, expected) {
+			t.Errorf("Tokens(%q): expected %v, got %v", input, expected, tokens)
+		}
+
+		words := Tokenize(input)
+		if len(words) != len(expected) {
+			t.Fatalf("Tokenize(%q): expected %d words, got %v", input, len(expected), words)
+		}
+		for i, word := range words {
+			if word != expected[i].Text {
+				t.Errorf("Tokenize(%q): expected %q at %d, got %q", input, expected[i].Text, i, word)
+			}
+		}
+	}
+}
+
+func TestTokenScanner(t *testing.T) {
+	for _, input := range tokenizerInputs {
+		// one byte at a time splits runes across reads
+		s := NewTokenScanner(iotest.OneByteReader(strings.NewReader(input)))
+		tokens := []Token{}
+		for s.Scan() {
+			tokens = append(tokens, s.Token())
+		}
+		if err := s.Err(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		s.Close()
+
+		if expected := referenceTokens(input); !reflect.DeepEqual(tokens, expected) {
+			t.Errorf("Scanning %q: expected %v, got %v", input, expected, tokens)
+		}
+	}
+}
+
+func TestTokenScanner_ReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+	s := NewTokenScanner(iotest.DataErrReader(iotest.TimeoutReader(strings.NewReader("first second third"))))
+	defer s.Close()
+
+	for s.Scan() {
+	}
+	if !errors.Is(s.Err(), iotest.ErrTimeout) {
+		t.Errorf("Expected a timeout, got %v", s.Err())
+	}
+	if s.Scan() {
+		t.Error("Expected no words after an error")
+	}
+
+	s = NewTokenScanner(iotest.ErrReader(readErr))
+	if s.Scan() || !errors.Is(s.Err(), readErr) {
+		t.Errorf("Expected %v, got %v", readErr, s.Err())
+	}
+}
+
+func TestTokenScanner_Close(t *testing.T) {
+	s := NewTokenScanner(strings.NewReader("one two"))
+	if !s.Scan() || string(s.Bytes()) != "one" {
+		t.Fatalf("Expected one, got %q", s.Bytes())
+	}
+	s.Close()
+	s.Close()
+	if s.Scan() {
+		t.Error("Expected a closed scanner to stop")
+	}
+}
+
+func TestTokenScanner_Reset(t *testing.T) {
+	s := NewTokenScanner(iotest.ErrReader(errors.New("closed")))
+	defer s.Close()
+	if s.Scan() || s.Err() == nil {
+		t.Fatal("Expected a read error")
+	}
+
+	s.Reset(strings.NewReader("Hello World"))
+	tokens := []Token{}
+	for s.Scan() {
+		tokens = append(tokens, s.Token())
+	}
+	expected := []Token{{Text: "hello", Start: 0, End: 5}, {Text: "world", Start: 6, End: 11}}
+	if s.Err() != nil || !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected %v, got %v (%v)", expected, tokens, s.Err())
+	}
+}
+
+func TestTokenize_Allocations(t *testing.T) {
+	text := "goroutines communicate over channels while packages export interfaces"
+	// the result slice only: lowercase ASCII words are substrings of text
+	if allocs := testing.AllocsPerRun(100, func() { Tokenize(text) }); allocs > 1 {
+		t.Errorf("Expected 1 allocation, got %v", allocs)
+	}
+
+	s := NewTokenScanner(strings.NewReader(""))
+	defer s.Close()
+	reader := strings.NewReader(text)
+	allocs := testing.AllocsPerRun(100, func() {
+		reader.Reset(text)
+		s.Reset(reader)
+		for s.Scan() {
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("Expected scanning to allocate nothing, got %v", allocs)
+	}
+}
+
+// benchmarkText mixes English, technical terms and Persian like a typical
+// article body.
+var benchmarkText = strings.Repeat("The Go programming language, created at Google in 2009, makes it easy "+
+	"to build simple, reliable and efficient software. Concurrency uses goroutines and channels; "+
+	"COVID-19 dashboards run on Node.js and C++ services. زبان برنامه‌نویسی گو ساده است. ", 40)
+
+func BenchmarkTokenize(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for b.Loop() {
+		Tokenize(benchmarkText)
+	}
+}
+
+func BenchmarkTokenize_Reference(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for b.Loop() {
+		referenceTokens(benchmarkText)
+	}
+}
+
+func BenchmarkTokenize_Regexp(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for b.Loop() {
+		regexpTokenize(benchmarkText)
+	}
+}
+
+func BenchmarkTokens(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for b.Loop() {
+		(*Tokenizer)(nil).Tokens(benchmarkText)
+	}
+}
+
+func BenchmarkTokens_Protected(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for b.Loop() {
+		builtinTokenizer.Tokens(benchmarkText)
+	}
+}
+
+// BenchmarkTokens_ProtectedRegexp matches the built-in patterns with their
+// compiled regular expressions, as the tokenizer did before scanning them.
+func BenchmarkTokens_ProtectedRegexp(b *testing.B) {
+	tokenizer, _ := NewTokenizer(DefaultProtectedPatterns)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for b.Loop() {
+		tokenizer.Tokens(benchmarkText)
+	}
+}
+
+func BenchmarkTokenScanner(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	reader := strings.NewReader(benchmarkText)
+	s := NewTokenScanner(reader)
+	defer s.Close()
+	for b.Loop() {
+		reader.Reset(benchmarkText)
+		s.Reset(reader)
+		for s.Scan() {
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// VersionedNames are the product names a dotted version number is kept with,
+// as in "Go 1.24". Other names only keep a version with a "v" prefix, as in
+// "Envoy v1.29", so "Revenue 3.5" and "In 2.5" are not protected. Names are
+// single words of letters.
+var VersionedNames = []string{
+	"Go", "Python", "Java", "Ruby", "Rust", "PHP", "Perl", "Swift", "Kotlin", "Scala",
+	"Node", "Deno", "Dart", "Julia", "Lua", "Elixir", "Erlang", "Haskell", "TypeScript",
+	"Angular", "React", "Vue", "Django", "Rails", "Spring", "Kubernetes", "Docker",
+	"PostgreSQL", "MySQL", "Redis", "MongoDB", "Linux", "Ubuntu", "Debian", "Fedora",
+	"Windows", "macOS", "iOS", "Android",
+}
+
+// DefaultProtectedPatterns match technical terms that plain tokenization
+// would split or mangle, such as "COVID-19", "C++", "C#", "node.js",
+// "ASP.NET" and "Go 1.24".
+var DefaultProtectedPatterns = []string{
+	// names with a numeric suffix: COVID-19, GPT-4, SARS-CoV-2
+	`\pL[\pL\pN]*(?:-\pL[\pL\pN]*)*-\pN[\pL\pN]*`,
+	// languages and tools with symbol suffixes: C++, g++, C#, F#
+	`\pL[\pL\pN]*\+\+|(?i:[cfj])#`,
+	// dotted library and framework names: node.js, vue.js, asp.net, socket.io
+	`(?i:\pL[\pL\pN]*\.(?:js|ts|net|io|py|rs))`,
+	// words mixing letters and digits: IPv6, ES2015, x86, 5G
+	`\pL[\pL\pN]*\pN[\pL\pN]*|\pN[\pL\pN]*\pL[\pL\pN]*`,
+	// known products followed by a dotted version: Go 1.24, Python 3.12
+	`(?:` + strings.Join(VersionedNames, "|") + `)[ \t]v?\pN+(?:\.\pN+)+`,
+	// capitalized names followed by a "v" version: Envoy v1.29
+	`\p{Lu}\pL*[ \t]v\pN+(?:\.\pN+)+`,
+}
+
+// builtinTokenizer protects the DefaultProtectedPatterns.
+var builtinTokenizer = &Tokenizer{builtin: true}
+
+// Token is a word of a text together with its byte offsets in that text.
+// Text is normalized and lowercase; letters that only some languages rewrite,
+// such as the Arabic yeh of Persian text, are kept until NormalizeTokens.
+// Protected tokens matched a protected pattern and are exempt from stop-word
+// filtering and stemming.
+type Token struct {
+	Text      string
+	Start     int
+	End       int
+	Protected bool
+}
+
+// Tokenizer splits text into tokens, keeping matches of its protected
+// patterns intact. A nil Tokenizer protects nothing.
+type Tokenizer struct {
+	// builtin protects the DefaultProtectedPatterns, matched while scanning
+	// runes rather than with their regular expressions
+	builtin   bool
+	protected *regexp.Regexp
+}
+
+// NewTokenizer compiles patterns into a tokenizer. Where matches overlap the
+// longest one wins.
+func NewTokenizer(patterns []string) (*Tokenizer, error) {
+	if len(patterns) == 0 {
+		return &Tokenizer{}, nil
+	}
+
+	alternatives := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("protected pattern %q: %w", pattern, err)
+		}
+		alternatives = append(alternatives, "(?:"+pattern+")")
+	}
+	re := regexp.MustCompile(strings.Join(alternatives, "|"))
+	re.Longest()
+	return &Tokenizer{protected: re}, nil
+}
+
+// NewProtectedTokenizer returns a tokenizer that protects the built-in
+// patterns and the patterns listed in the file at path. An empty path adds
+// no patterns. Only the patterns of the file are compiled; the built-in ones
+// are matched while scanning.
+func NewProtectedTokenizer(path string) (*Tokenizer, error) {
+	if path == "" {
+		return builtinTokenizer, nil
+	}
+
+	patterns, err := LoadProtectedPatterns(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewTokenizer(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	t.builtin = true
+	return t, nil
+}
+
+// LoadProtectedPatterns reads one regular expression per line from the file at
+// path. Blank lines and lines starting with '#' are skipped.
+func LoadProtectedPatterns(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, nil
+}
+
+// Tokenize returns the text of every token of text. Without protected
+// patterns the words are scanned straight into the result.
+func (t *Tokenizer) Tokenize(text string) []string {
+	if t == nil || !t.builtin && t.protected == nil {
+		words := make([]string, 0, estimatedWords(text))
+		var buf []byte
+		for start, end := nextWord(text, 0); start >= 0; start, end = nextWord(text, end) {
+			var word string
+			if word, buf = normalizedWord(text[start:end], buf); word != "" {
+				words = append(words, word)
+			}
+		}
+		return words
+	}
+
+	tokens := t.Tokens(text)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+	return words
+}
+
+// Tokens splits text into tokens. Outside protected matches a token is a run
+// of Unicode letters, numbers and combining marks; a zero-width non-joiner
+// inside a word is kept as part of it.
+func (t *Tokenizer) Tokens(text string) []Token {
+	matches := t.protectedMatches(text)
+	tokens := make([]Token, 0, estimatedWords(text))
+	pos := 0
+	for _, match := range matches {
+		tokens = appendWords(tokens, text, pos, match[0])
+		// whitespace inside a match such as "Go 1.24" collapses to one space
+		word := strings.Join(strings.Fields(normalizeWord(text[match[0]:match[1]])), " ")
+		if word != "" {
+			tokens = append(tokens, Token{Text: word, Start: match[0], End: match[1], Protected: true})
+		}
+		pos = match[1]
+	}
+	return appendWords(tokens, text, pos, len(text))
+}
+
+// protectedMatches returns the protected matches of text that do not start
+// or end inside a word.
+func (t *Tokenizer) protectedMatches(text string) [][2]int {
+	if t == nil {
+		return nil
+	}
+	var builtin, compiled [][2]int
+	if t.builtin {
+		builtin = builtinMatches(text)
+	}
+	if t.protected != nil {
+		for _, match := range t.protected.FindAllStringIndex(text, -1) {
+			compiled = append(compiled, [2]int{match[0], match[1]})
+		}
+	}
+
+	matches := [][2]int{}
+	for _, match := range mergeMatches(builtin, compiled) {
+		first, _ := utf8.DecodeRuneInString(text[match[0]:])
+		before, _ := utf8.DecodeLastRuneInString(text[:match[0]])
+		last, _ := utf8.DecodeLastRuneInString(text[:match[1]])
+		after, _ := utf8.DecodeRuneInString(text[match[1]:])
+		if match[0] > 0 && isWordRune(first) && isWordRune(before) {
+			continue
+		}
+		if match[1] < len(text) && isWordRune(last) && isWordRune(after) {
+			continue
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// mergeMatches merges two lists of matches ordered by position into one
+// without overlaps. Where matches overlap the one starting first wins, and
+// of those starting alike the longest.
+func mergeMatches(a, b [][2]int) [][2]int {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+
+	merged := make([][2]int, 0, len(a)+len(b))
+	end := 0
+	for len(a) > 0 || len(b) > 0 {
+		var next [2]int
+		if len(b) == 0 || len(a) > 0 && (a[0][0] < b[0][0] || a[0][0] == b[0][0] && a[0][1] >= b[0][1]) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+		if next[0] >= end {
+			merged = append(merged, next)
+			end = next[1]
+		}
+	}
+	return merged
+}
+
+// appendWords appends the words of text[start:end].
+func appendWords(tokens []Token, text string, start, end int) []Token {
+	text = text[:end]
+	var buf []byte
+	for wordStart, wordEnd := nextWord(text, start); wordStart >= 0; wordStart, wordEnd = nextWord(text, wordEnd) {
+		var word string
+		if word, buf = normalizedWord(text[wordStart:wordEnd], buf); word != "" {
+			tokens = append(tokens, Token{Text: word, Start: wordStart, End: wordEnd})
+		}
+	}
+	return tokens
+}
+
+// nextWord returns the byte offsets of the first word of text at or after
+// pos, or a start of -1 if there is none.
+func nextWord(text string, pos int) (start, end int) {
+	start = -1
+	for i := pos; i < len(text); {
+		if c := text[i]; c < utf8.RuneSelf {
+			if asciiWordBytes[c] {
+				if start < 0 {
+					start = i
+				}
+			} else if start >= 0 {
+				return start, i
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if isWordRune(r) || ((r == zwnj || r == zwj) && start >= 0) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			return start, i
+		}
+		i += size
+	}
+	return start, len(text)
+}
+
+// estimatedWords estimates the number of words of text to size token
+// slices, assuming six bytes per word and separator as in English prose.
+// Counting them exactly would take a second pass over text.
+func estimatedWords(text string) int {
+	return len(text)/6 + 1
+}
+
+// asciiWordBytes marks the ASCII letters and digits.
+var asciiWordBytes = func() (table [utf8.RuneSelf]bool) {
+	for c := range table {
+		table[c] = 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+	}
+	return table
+}()
+
+func isWordRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return asciiWordBytes[r]
+	}
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+// normalizeWord strips the diacritics and tatweel of the Arabic script,
+// lowercases word and trims dangling joiners.
+func normalizeWord(word string) string {
+	word, _ = normalizedWord(word, nil)
+	return word
+}
+
+// normalizedWord returns the normalized form of word and buf, which holds
+// scratch space for the next call. Words that normalizing leaves unchanged,
+// such as lowercase ASCII words, are returned without allocating.
+func normalizedWord(word string, buf []byte) (string, []byte) {
+	if isNormalizedASCII(word) {
+		return word, buf
+	}
+	buf = appendNormalizedWord(buf[:0], word)
+	if string(buf) == word {
+		return word, buf
+	}
+	return string(buf), buf
+}
+
+// isNormalizedASCII reports whether word is ASCII without uppercase letters.
+func isNormalizedASCII(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if c := word[i]; c >= utf8.RuneSelf || 'A' <= c && c <= 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// appendNormalizedWord appends word to buf with the Arabic script
+// normalized, letters lowercased and dangling joiners trimmed.
+func appendNormalizedWord(buf []byte, word string) []byte {
+	for _, r := range word {
+		buf = appendNormalizedRune(buf, r)
+	}
+	return trimJoiners(buf)
+}
+
+// appendNormalizedRune appends the normalized form of r to buf.
+func appendNormalizedRune(buf []byte, r rune) []byte {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return append(buf, byte(r))
+	}
+	if r = normalizeScriptRune(r); r < 0 {
+		return buf
+	}
+	return utf8.AppendRune(buf, unicode.ToLower(r))
+}
+
+// trimJoiners trims the zero-width non-joiners ending buf.
+func trimJoiners(buf []byte) []byte {
+	for len(buf) >= utf8.RuneLen(zwnj) {
+		r, size := utf8.DecodeLastRune(buf)
+		if r != zwnj {
+			break
+		}
+		buf = buf[:len(buf)-size]
+	}
+	return buf
+}
+
+// NormalizeTokens rewrites the letters of tokens to the forms used by
+// language, as NormalizeLetters does, and returns tokens.
+func NormalizeTokens(language string, tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Text = NormalizeLetters(language, tokens[i].Text)
+	}
+	return tokens
+}
+
+// Fragments splits text at punctuation and blank lines into fragments that
+// can hold a phrase. Punctuation inside a protected token does not split it.
+func (t *Tokenizer) Fragments(text string) [][]Token {
+	fragments := [][]Token{}
+	var current []Token
+	end := 0
+	for _, token := range t.Tokens(text) {
+		if len(current) > 0 && isFragmentBreak(text[end:token.Start]) {
+			fragments = append(fragments, current)
+			current = nil
+		}
+		current = append(current, token)
+		end = token.End
+	}
+	if len(current) > 0 {
+		fragments = append(fragments, current)
+	}
+	return fragments
+}
+
+// isFragmentBreak reports whether the text between two tokens holds
+// punctuation or a blank line.
+func isFragmentBreak(gap string) bool {
+	newlines := 0
+	for _, r := range gap {
+		switch {
+		case r == '\n':
+			newlines++
+		case !unicode.IsSpace(r) && r != zwnj && r != zwj:
+			return true
+		}
+	}
+	return newlines >= 2
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTokenizer_Tokenize(t *testing.T) {
+	compiled, err := NewTokenizer(DefaultProtectedPatterns)
+	if err != nil {
+		t.Fatalf("NewTokenizer failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Hyphenated names with numbers",
+			input:    "COVID-19 and SARS-CoV-2 cases, GPT-4 benchmarks",
+			expected: []string{"covid-19", "and", "sars-cov-2", "cases", "gpt-4", "benchmarks"},
+		},
+		{
+			name:     "Symbol suffixes",
+			input:    "C++, C# and g++ compilers",
+			expected: []string{"c++", "c#", "and", "g++", "compilers"},
+		},
+		{
+			name:     "Dotted names at the end of a sentence",
+			input:    "We moved from ASP.NET to node.js.",
+			expected: []string{"we", "moved", "from", "asp.net", "to", "node.js"},
+		},
+		{
+			name:     "Mixed letters and digits",
+			input:    "IPv6 support in ES2015 for x86",
+			expected: []string{"ipv6", "support", "in", "es2015", "for", "x86"},
+		},
+		{
+			name:     "Versioned names",
+			input:    "Go 1.24 and Python  3.12 released; version 2.0 is not a name",
+			expected: []string{"go 1.24", "and", "python", "3", "12", "released", "version", "2", "0", "is", "not", "a", "name"},
+		},
+		{
+			name:     "Names with a v version",
+			input:    "Envoy v1.29 and Kubernetes v1.30",
+			expected: []string{"envoy v1.29", "and", "kubernetes v1.30"},
+		},
+		{
+			name:     "Capitalized words before a decimal are not versions",
+			input:    "Revenue 3.5 million",
+			expected: []string{"revenue", "3", "5", "million"},
+		},
+		{
+			name:     "Sentence starts before a decimal are not versions",
+			input:    "In 2.5 seconds",
+			expected: []string{"in", "2", "5", "seconds"},
+		},
+		{
+			name:     "Known names inside words are not versions",
+			input:    "Lego 1.5",
+			expected: []string{"lego", "1", "5"},
+		},
+		{
+			name:     "Matches inside words are ignored",
+			input:    "abc#def",
+			expected: []string{"abc", "def"},
+		},
+		{
+			name:     "Persian text is unchanged",
+			input:    "می‌روم به خانه",
+			expected: []string{"می‌روم", "به", "خانه"},
+		},
+	}
+
+	// the built-in patterns are scanned, not compiled, but match alike
+	for _, tokenizer := range []*Tokenizer{builtinTokenizer, compiled} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result := tokenizer.Tokenize(tt.input)
+				if !reflect.DeepEqual(result, tt.expected) {
+					t.Errorf("Expected %q, got %q", tt.expected, result)
+				}
+			})
+		}
+	}
+}
+
+func TestBuiltinMatches(t *testing.T) {
+	compiled, _ := NewTokenizer(DefaultProtectedPatterns)
+	inputs := append([]string{
+		benchmarkText,
+		"SARS-CoV-2, COVID-19-like, GPT-4o and Wi-Fi-6E; pre-2020 and x-",
+		"C++/CLI, g++, c#, F# and J#, but not abc#def or C+",
+		"Node.JS, ASP.NET Core, socket.io, numpy.py, tokio.rs, vue.jsx and .js",
+		"IPv6 ES2015 x86 5G 3D 2024 année2024 Windows11",
+		"Go 1.24.1, Python\t3.12, Rust v1.80.0, Go 1.x, Go 1., Gopher 1.2, XGo 1.2",
+		"Envoy v1.29, Revenue 3.5, envoy v1.29, Ünïcode v2.0, Kubernetes v1.30rc",
+		"١٢٣ فارسی۱۴۰۲ ۱۴۰۲-ها Ⅻ-7",
+	}, tokenizerInputs...)
+
+	for _, input := range inputs {
+		if scanned, expected := builtinTokenizer.Tokens(input), compiled.Tokens(input); !reflect.DeepEqual(scanned, expected) {
+			t.Errorf("Tokens(%q): expected %v, got %v", input, expected, scanned)
+		}
+	}
+}
+
+func TestTokenizer_Tokens(t *testing.T) {
+	text := "Learn C++ today"
+
+	tokens := builtinTokenizer.Tokens(text)
+	expected := []Token{
+		{Text: "learn", Start: 0, End: 5},
+		{Text: "c++", Start: 6, End: 9, Protected: true},
+		{Text: "today", Start: 10, End: 15},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Fatalf("Expected %v, got %v", expected, tokens)
+	}
+	for _, token := range tokens {
+		if token.Protected && text[token.Start:token.End] != "C++" {
+			t.Errorf("Expected offsets of the original text, got %q", text[token.Start:token.End])
+		}
+	}
+}
+
+func TestTokenizer_Nil(t *testing.T) {
+	var tokenizer *Tokenizer
+	input := "COVID-19 and C++ in Go 1.24"
+
+	if result := tokenizer.Tokenize(input); !reflect.DeepEqual(result, Tokenize(input)) {
+		t.Errorf("Expected a nil tokenizer to match Tokenize, got %q", result)
+	}
+}
+
+func TestTokenizer_Fragments(t *testing.T) {
+	var result [][]string
+	for _, fragment := range builtinTokenizer.Fragments("Node.js and C++ tooling. Go 1.24 ships!\n\nnext paragraph") {
+		words := []string{}
+		for _, token := range fragment {
+			words = append(words, token.Text)
+		}
+		result = append(result, words)
+	}
+
+	expected := [][]string{{"node.js", "and", "c++", "tooling"}, {"go 1.24", "ships"}, {"next", "paragraph"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestNewTokenizer_InvalidPattern(t *testing.T) {
+	if _, err := NewTokenizer([]string{`(unclosed`}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+func TestNewProtectedTokenizer(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("user patterns", func(t *testing.T) {
+		path := filepath.Join(dir, "patterns.txt")
+		if err := os.WriteFile(path, []byte("# product names\n\n(?i:web\\s?3)\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		tokenizer, err := NewProtectedTokenizer(path)
+		if err != nil {
+			t.Fatalf("NewProtectedTokenizer failed: %v", err)
+		}
+		expected := []string{"web 3", "and", "c++"}
+		if result := tokenizer.Tokenize("Web 3 and C++"); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("user patterns overlapping built-in ones", func(t *testing.T) {
+		path := filepath.Join(dir, "overlapping.txt")
+		if err := os.WriteFile(path, []byte("Go 1\\.24 LTS\nand C\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		tokenizer, err := NewProtectedTokenizer(path)
+		if err != nil {
+			t.Fatalf("NewProtectedTokenizer failed: %v", err)
+		}
+		// the longest match wins, and of overlapping ones the first
+		expected := []string{"go 1.24 lts", "and c", "go 1.24"}
+		if result := tokenizer.Tokenize("Go 1.24 LTS and C++ Go 1.24"); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.txt")
+		if err := os.WriteFile(path, []byte("[a-"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewProtectedTokenizer(path); err == nil {
+			t.Error("Expected an error for an invalid pattern")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := NewProtectedTokenizer(filepath.Join(dir, "missing.txt")); err == nil {
+			t.Error("Expected an error for a missing file")
+		}
+	})
+}